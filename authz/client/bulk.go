@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+// DefaultChunkSize is the default number of updates written in a single
+// request by WriteRelationshipsBulk. It's the spicedb default
+// of the maximum updates per write.
+const DefaultChunkSize = 1000
+
+// BulkOption configures WriteRelationshipsBulk.
+type BulkOption func(*bulkOptions)
+
+type bulkOptions struct {
+	chunkSize   int
+	concurrency int
+}
+
+// WithChunkSize sets the number of updates written in a single request.
+// It must not exceed the maximum updates per write of the server.
+func WithChunkSize(size int) BulkOption {
+	return func(o *bulkOptions) {
+		if size > 0 {
+			o.chunkSize = size
+		}
+	}
+}
+
+// WithConcurrency sets the number of chunks written concurrently.
+// By default chunks are written one after another.
+func WithConcurrency(n int) BulkOption {
+	return func(o *bulkOptions) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// BulkReport reports the result of WriteRelationshipsBulk.
+type BulkReport struct {
+	// Written is the number of written updates.
+	Written int
+
+	// Failed lists the chunks which failed to be written.
+	Failed []*BulkFailure
+
+	// WrittenAt is the token of the last written chunk.
+	// It's nil if chunks were written concurrently, because
+	// there's no single token covering all of them.
+	WrittenAt *pb.ZedToken
+}
+
+// BulkFailure is a chunk of updates which failed to be written.
+// None of its updates is written.
+type BulkFailure struct {
+	Updates []*pb.RelationshipUpdate
+	Err     error
+}
+
+// FailedRelationships returns relationships of all failed chunks.
+func (r *BulkReport) FailedRelationships() []*pb.Relationship {
+	var rels []*pb.Relationship
+	for _, f := range r.Failed {
+		for _, u := range f.Updates {
+			rels = append(rels, u.Relationship)
+		}
+	}
+	return rels
+}
+
+// Err joins errors of all failed chunks, it's nil if all chunks were written.
+func (r *BulkReport) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(r.Failed))
+	for i, f := range r.Failed {
+		errs[i] = f.Err
+	}
+	return fmt.Errorf(
+		"authz: write relationships bulk: %d updates failed: %w",
+		len(r.FailedRelationships()), errors.Join(errs...),
+	)
+}
+
+// WriteRelationshipsBulk writes any number of updates, split into chunks
+// which fit into a single request. Each chunk is written atomically,
+// but the whole write is not: a failed chunk doesn't stop the others.
+// The returned error is BulkReport.Err, the report is always returned.
+//
+// Chunks without create operations are retried, see WithRetry.
+func (c *Client) WriteRelationshipsBulk(
+	ctx context.Context,
+	updates []*pb.RelationshipUpdate,
+	opts ...BulkOption,
+) (*BulkReport, error) {
+	o := &bulkOptions{
+		chunkSize:   DefaultChunkSize,
+		concurrency: 1,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var (
+		report = &BulkReport{}
+		mx     sync.Mutex
+		wg     sync.WaitGroup
+		sem    = make(chan struct{}, o.concurrency)
+	)
+	for i := 0; i < len(updates); i += o.chunkSize {
+		chunk := updates[i:min(i+o.chunkSize, len(updates))]
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			tx := &Tx{c: c, updates: chunk}
+			token, err := tx.write(ctx)
+
+			mx.Lock()
+			defer mx.Unlock()
+			if err != nil {
+				report.Failed = append(report.Failed, &BulkFailure{Updates: chunk, Err: err})
+				return
+			}
+			report.Written += len(chunk)
+			if o.concurrency == 1 {
+				report.WrittenAt = token
+			}
+		}()
+	}
+	wg.Wait()
+
+	setZedToken(ctx, report.WrittenAt)
+	return report, report.Err()
+}
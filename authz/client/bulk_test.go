@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWriteRelationshipsBulk(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const writeMethod = "/authzed.api.v1.PermissionsService/WriteRelationships"
+
+	var writes atomic.Int32
+	tclient, err := StartTestServer(ctx, WithUnaryInterceptors(
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if method == writeMethod {
+				writes.Add(1)
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		},
+	))
+	assert.NoError(t, err)
+
+	offDayUpdates := func(orgId string, n int) []*pb.RelationshipUpdate {
+		updates := make([]*pb.RelationshipUpdate, n)
+		for i := range updates {
+			updates[i] = &pb.RelationshipUpdate{
+				Operation:    pb.RelationshipUpdate_OPERATION_TOUCH,
+				Relationship: RelationOffDayOrganization(fmt.Sprintf("offday%04d", i), orgId),
+			}
+		}
+		return updates
+	}
+
+	countOffDays := func(t *testing.T, orgId string) int {
+		rels, err := tclient.ReadRelationships(ctx, &pb.RelationshipFilter{
+			ResourceType: definitionOffDay,
+			OptionalSubjectFilter: &pb.SubjectFilter{
+				SubjectType:       definitionOrganization,
+				OptionalSubjectId: orgId,
+			},
+		})
+		assert.NoError(t, err)
+		return len(rels)
+	}
+
+	t.Run("chunks", func(t *testing.T) {
+		writes.Store(0)
+		report, err := tclient.WriteRelationshipsBulk(ctx, offDayUpdates("chunks", 2500))
+		assert.NoError(t, err)
+		assert.Equal(t, report.Written, 2500)
+		assert.Len(t, report.Failed, 0)
+		assert.True(t, report.WrittenAt != nil)
+		assert.Equal(t, writes.Load(), int32(3))
+		assert.Equal(t, countOffDays(t, "chunks"), 2500)
+	})
+
+	t.Run("concurrency", func(t *testing.T) {
+		writes.Store(0)
+		report, err := tclient.WriteRelationshipsBulk(ctx, offDayUpdates("concurrency", 1000),
+			WithChunkSize(100),
+			WithConcurrency(4),
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, report.Written, 1000)
+		assert.Nil(t, report.WrittenAt)
+		assert.Equal(t, writes.Load(), int32(10))
+		assert.Equal(t, countOffDays(t, "concurrency"), 1000)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		writes.Store(0)
+		report, err := tclient.WriteRelationshipsBulk(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, report.Written, 0)
+		assert.Equal(t, writes.Load(), int32(0))
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		f := &failer{method: writeMethod, code: codes.InvalidArgument, failures: 1}
+		tclient, err := StartTestServer(ctx, f.options())
+		assert.NoError(t, err)
+
+		updates := offDayUpdates("failures", 30)
+		report, err := tclient.WriteRelationshipsBulk(ctx, updates, WithChunkSize(10))
+		assert.ErrorContains(t, err, "10 updates failed")
+		assert.Equal(t, report.Written, 20)
+		assert.Len(t, report.Failed, 1)
+		assert.Equal(t, status.Code(report.Failed[0].Err), codes.InvalidArgument)
+
+		failed := report.FailedRelationships()
+		assert.Len(t, failed, 10)
+		assert.Equal(t, failed[0], updates[0].Relationship)
+	})
+}
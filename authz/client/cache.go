@@ -0,0 +1,276 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxWatchedTokens limits the number of remembered watch tokens,
+// see checkCache.fresh.
+const maxWatchedTokens = 1024
+
+// CacheStats reports usage of the check cache, see WithCheckCache.
+type CacheStats struct {
+	// Hits are checks answered from the cache.
+	Hits uint64
+	// Misses are checks without a fresh enough cached result.
+	Misses uint64
+	// Bypasses are fully consistent checks, they never use the cache.
+	Bypasses uint64
+	// Evictions are results removed to make room for new ones.
+	Evictions uint64
+	// Flushes are invalidations of the whole cache by the watch.
+	Flushes uint64
+}
+
+// checkCache is a LRU cache of permission check results.
+//
+// A relationship change may affect any permission, so every change
+// received by WatchCheckCache flushes the whole cache.
+// Tokens received by the watch order the flushes, see checkCache.fresh.
+type checkCache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mx      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	// generation is increased by every flush, so results of checks
+	// started before the flush are not cached.
+	generation uint64
+	// watched orders tokens received by the watch.
+	watched map[string]uint64
+	stats   CacheStats
+}
+
+type cacheEntry struct {
+	key       string
+	granted   bool
+	checkedAt *pb.ZedToken
+	expires   time.Time
+}
+
+func (o *options) newCheckCache() *checkCache {
+	if o.cacheSize == 0 {
+		return nil
+	}
+	return &checkCache{
+		size:    o.cacheSize,
+		ttl:     o.cacheTTL,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		watched: make(map[string]uint64),
+	}
+}
+
+// check returns the cached result of the check,
+// or calls the check and caches its result.
+// Conditional results depend on the missing context, so they are not cached.
+func (cc *checkCache) check(
+	req *pb.CheckPermissionRequest,
+	check func() (*pb.CheckPermissionResponse, error),
+) error {
+	key, err := cacheKey(req)
+	if err != nil {
+		return err
+	}
+
+	granted, generation, ok := cc.get(key, req.Consistency)
+	if ok {
+		if granted {
+			return nil
+		}
+		return newErrDenied(req.Resource, req.Permission, req.Subject)
+	}
+
+	resp, err := check()
+	if err != nil {
+		return err
+	}
+
+	denied := permissionship(req, resp)
+	if denied == nil || !denied.Conditional {
+		cc.put(key, denied == nil, resp.CheckedAt, generation)
+	}
+	if denied != nil {
+		return denied
+	}
+	return nil
+}
+
+func (cc *checkCache) get(key string, consistency *pb.Consistency) (granted bool, generation uint64, ok bool) {
+	cc.mx.Lock()
+	defer cc.mx.Unlock()
+
+	if consistency.GetFullyConsistent() {
+		cc.stats.Bypasses++
+		return false, cc.generation, false
+	}
+
+	elem, ok := cc.entries[key]
+	if !ok {
+		cc.stats.Misses++
+		return false, cc.generation, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if cc.now().After(entry.expires) {
+		cc.remove(elem)
+		cc.stats.Misses++
+		return false, cc.generation, false
+	}
+	if !cc.fresh(entry, consistency.GetAtLeastAsFresh()) {
+		cc.stats.Misses++
+		return false, cc.generation, false
+	}
+
+	cc.lru.MoveToFront(elem)
+	cc.stats.Hits++
+	return entry.granted, cc.generation, true
+}
+
+// fresh reports if the entry is at least as fresh as the token.
+// Tokens can't be compared, unless they are the same or both
+// were received by the watch, so other entries are treated as stale.
+func (cc *checkCache) fresh(entry *cacheEntry, token *pb.ZedToken) bool {
+	if token == nil || entry.checkedAt.GetToken() == token.GetToken() {
+		return true
+	}
+
+	checkedAt, ok := cc.watched[entry.checkedAt.GetToken()]
+	if !ok {
+		return false
+	}
+	required, ok := cc.watched[token.GetToken()]
+	return ok && checkedAt >= required
+}
+
+func (cc *checkCache) put(key string, granted bool, checkedAt *pb.ZedToken, generation uint64) {
+	cc.mx.Lock()
+	defer cc.mx.Unlock()
+
+	if generation != cc.generation {
+		// the cache was flushed during the check
+		return
+	}
+
+	entry := &cacheEntry{
+		key:       key,
+		granted:   granted,
+		checkedAt: checkedAt,
+		expires:   cc.now().Add(cc.ttl),
+	}
+	if elem, ok := cc.entries[key]; ok {
+		elem.Value = entry
+		cc.lru.MoveToFront(elem)
+		return
+	}
+
+	cc.entries[key] = cc.lru.PushFront(entry)
+	if cc.lru.Len() > cc.size {
+		cc.remove(cc.lru.Back())
+		cc.stats.Evictions++
+	}
+}
+
+func (cc *checkCache) remove(elem *list.Element) {
+	cc.lru.Remove(elem)
+	delete(cc.entries, elem.Value.(*cacheEntry).key)
+}
+
+// flush removes all entries.
+func (cc *checkCache) flush() {
+	cc.mx.Lock()
+	defer cc.mx.Unlock()
+	cc.flushLocked()
+}
+
+func (cc *checkCache) flushLocked() {
+	clear(cc.entries)
+	cc.lru.Init()
+	cc.generation++
+	cc.stats.Flushes++
+}
+
+// watch flushes the cache if the watch received relationship changes
+// and remembers the token of the changes.
+func (cc *checkCache) watch(resp *pb.WatchResponse) {
+	cc.mx.Lock()
+	defer cc.mx.Unlock()
+
+	if len(resp.Updates) > 0 {
+		cc.flushLocked()
+	}
+	if len(cc.watched) >= maxWatchedTokens {
+		// older tokens are forgotten, entries checked at them are stale
+		clear(cc.watched)
+	}
+	cc.watched[resp.ChangesThrough.GetToken()] = cc.generation
+}
+
+// cacheKey identifies the check by the resource, permission,
+// subject and caveat context.
+func cacheKey(req *pb.CheckPermissionRequest) (string, error) {
+	key := relstr(req)
+	if req.Context == nil {
+		return key, nil
+	}
+
+	caveatContext, err := proto.MarshalOptions{Deterministic: true}.Marshal(req.Context)
+	if err != nil {
+		return "", fmt.Errorf("authz: check permission %q: %w", key, err)
+	}
+	return key + "|" + string(caveatContext), nil
+}
+
+// CheckCacheStats returns statistics of the check cache.
+// It returns zero stats if the cache is disabled.
+func (c *Client) CheckCacheStats() CacheStats {
+	if c.checkCache == nil {
+		return CacheStats{}
+	}
+
+	c.checkCache.mx.Lock()
+	defer c.checkCache.mx.Unlock()
+	return c.checkCache.stats
+}
+
+// WatchCheckCache invalidates the check cache with relationship changes
+// received from the watch api. It blocks until ctx is done or the watch
+// fails, so it should run in a goroutine for the lifetime of the client,
+// and be restarted on error. Without it cached results
+// are used until their ttl expires.
+func (c *Client) WatchCheckCache(ctx context.Context) error {
+	if c.checkCache == nil {
+		return errors.New("authz: watch check cache: cache is disabled")
+	}
+
+	stream, err := c.c.Watch(ctx, &pb.WatchRequest{})
+	if err != nil {
+		return fmt.Errorf("authz: watch check cache: %w", err)
+	}
+
+	// changes made before the watch started are not received
+	c.checkCache.flush()
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			// changes made after the watch failed are not received
+			c.checkCache.flush()
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("authz: watch check cache: %w", err)
+		}
+		c.checkCache.watch(resp)
+	}
+}
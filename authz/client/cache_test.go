@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/grpc"
+)
+
+func TestCheckCache(t *testing.T) {
+	if debugTraces {
+		t.Skip("checks bypass the cache in authzdebug builds")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const checkMethod = "/authzed.api.v1.PermissionsService/CheckPermission"
+
+	// checks are at least as fresh as the setup, because minimize latency
+	// may read a snapshot before it
+	var checks atomic.Int32
+	var fresh ReadOption
+	var setupToken *pb.ZedToken
+	newClient := func(t *testing.T, size int, ttl time.Duration) *Client {
+		checks.Store(0)
+		tclient, err := StartTestServer(ctx,
+			WithCheckCache(size, ttl),
+			WithUnaryInterceptors(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				if method == checkMethod {
+					checks.Add(1)
+				}
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}),
+		)
+		assert.NoError(t, err)
+
+		_, err = tclient.WriteOrganizationAdmin(ctx, "rift", "alice")
+		assert.NoError(t, err)
+		var token *pb.ZedToken
+		for _, id := range []string{"offday1", "offday2", "offday3"} {
+			token, err = tclient.WriteOffDayOrganization(ctx, id, "rift")
+			assert.NoError(t, err)
+		}
+		fresh, setupToken = AtLeastAsFresh(token), token
+		return tclient
+	}
+
+	alice := MemberPrincipal("alice")
+	bob := MemberPrincipal("bob")
+
+	t.Run("hits", func(t *testing.T) {
+		tclient := newClient(t, 10, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			err := tclient.CanViewOffDay(ctx, "offday1", alice, fresh)
+			assert.NoError(t, err)
+
+			err = tclient.CanViewOffDay(ctx, "offday1", bob, fresh)
+			assert.ErrorContains(t, err, &ErrDenied{})
+		}
+		assert.Equal(t, checks.Load(), int32(2))
+		assert.Equal(t, tclient.CheckCacheStats(), CacheStats{Hits: 4, Misses: 2})
+
+		// cached results are fresh enough for minimize latency
+		err := tclient.CanViewOffDay(ctx, "offday1", alice, MinimizeLatency())
+		assert.NoError(t, err)
+		assert.Equal(t, checks.Load(), int32(2))
+	})
+
+	t.Run("fully_consistent", func(t *testing.T) {
+		tclient := newClient(t, 10, time.Minute)
+
+		// reads of the test client are fully consistent by default
+		for i := 0; i < 2; i++ {
+			err := tclient.CanViewOffDay(ctx, "offday1", alice)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, checks.Load(), int32(2))
+		assert.Equal(t, tclient.CheckCacheStats(), CacheStats{Bypasses: 2})
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		tclient := newClient(t, 10, time.Minute)
+		now := time.Now()
+		tclient.checkCache.now = func() time.Time { return now }
+
+		err := tclient.CanViewOffDay(ctx, "offday1", alice, fresh)
+		assert.NoError(t, err)
+
+		now = now.Add(2 * time.Minute)
+		err = tclient.CanViewOffDay(ctx, "offday1", alice, fresh)
+		assert.NoError(t, err)
+		assert.Equal(t, checks.Load(), int32(2))
+		assert.Equal(t, tclient.CheckCacheStats(), CacheStats{Misses: 2})
+	})
+
+	t.Run("lru", func(t *testing.T) {
+		tclient := newClient(t, 2, time.Minute)
+
+		for _, id := range []string{"offday1", "offday2", "offday1", "offday3", "offday1"} {
+			err := tclient.CanViewOffDay(ctx, id, alice, fresh)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, tclient.CheckCacheStats(), CacheStats{Hits: 2, Misses: 3, Evictions: 1})
+
+		// offday2 was the least recently used
+		err := tclient.CanViewOffDay(ctx, "offday2", alice, fresh)
+		assert.NoError(t, err)
+		assert.Equal(t, tclient.CheckCacheStats().Misses, uint64(4))
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		tclient := newClient(t, 10, time.Minute)
+
+		watchCtx, stopWatch := context.WithCancel(ctx)
+		watched := make(chan error)
+		go func() { watched <- tclient.WatchCheckCache(watchCtx) }()
+
+		// the watch may start before the setup writes,
+		// so it's synced when it receives the token of the last write
+		waitWatched := func(t *testing.T, token *pb.ZedToken) {
+			t.Helper()
+			deadline := time.Now().Add(5 * time.Second)
+			for {
+				tclient.checkCache.mx.Lock()
+				_, ok := tclient.checkCache.watched[token.GetToken()]
+				tclient.checkCache.mx.Unlock()
+				if ok {
+					return
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("token %s not watched", token.GetToken())
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+		waitWatched(t, setupToken)
+
+		err := tclient.CanViewOffDay(ctx, "offday1", bob, fresh)
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		token, err := tclient.WriteOrganizationSDR(ctx, "rift", "bob")
+		assert.NoError(t, err)
+		waitWatched(t, token)
+
+		err = tclient.CanViewOffDay(ctx, "offday1", bob, AtLeastAsFresh(token))
+		assert.NoError(t, err)
+
+		// the token was received by the watch, so the cached result is fresh
+		err = tclient.CanViewOffDay(ctx, "offday1", bob, AtLeastAsFresh(token))
+		assert.NoError(t, err)
+
+		stats := tclient.CheckCacheStats()
+		assert.Equal(t, stats.Hits, uint64(1))
+		assert.Equal(t, stats.Misses, uint64(2))
+
+		stopWatch()
+		assert.NoError(t, <-watched)
+	})
+
+	t.Run("watch_timeout", func(t *testing.T) {
+		// the default timeout doesn't apply to the long-lived watch
+		tclient, err := StartTestServer(ctx, WithCheckCache(10, time.Minute), WithTimeout(500*time.Millisecond))
+		assert.NoError(t, err)
+
+		watchCtx, stopWatch := context.WithCancel(ctx)
+		watched := make(chan error)
+		go func() { watched <- tclient.WatchCheckCache(watchCtx) }()
+
+		select {
+		case err := <-watched:
+			t.Fatalf("watch stopped: %v", err)
+		case <-time.After(time.Second):
+		}
+		assert.Equal(t, tclient.CheckCacheStats().Flushes, uint64(1))
+
+		stopWatch()
+		assert.NoError(t, <-watched)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		tclient, err := StartTestServer(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, tclient.CheckCacheStats(), CacheStats{})
+		assert.ErrorContains(t, tclient.WatchCheckCache(ctx), "cache is disabled")
+
+		_, err = StartTestServer(ctx, WithCheckCache(0, time.Minute))
+		assert.ErrorContains(t, err, "must be positive")
+	})
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+// cascadePageSize is the number of resources deleted at once
+// by a cascading deletion, so large owners are deleted in parts.
+const cascadePageSize = 1000
+
+// dependent is a definition owned by a parent resource through
+// the relation, e.g. sequence#organization. Dependents are generated
+// from the schema, e.g. organizationDependents.
+type dependent struct {
+	definition string
+	relation   string
+	dependents []*dependent
+}
+
+// DeleteReport reports relationships removed by a cascading deletion.
+type DeleteReport struct {
+	// Deleted is the number of deleted relationships
+	// keyed by the resource definition, e.g. sequence.
+	Deleted map[string]int
+}
+
+// Total returns the number of all deleted relationships.
+func (r *DeleteReport) Total() int {
+	total := 0
+	for _, n := range r.Deleted {
+		total += n
+	}
+	return total
+}
+
+// deleteCascade deletes the resource with all resources it owns.
+//
+// Owned resources are deleted before their owner and the relationship
+// to the owner is deleted last, so an interrupted deletion leaves
+// every remaining resource reachable from the owner. Calling it again
+// resumes the deletion.
+func (c *Client) deleteCascade(
+	ctx context.Context,
+	definition string,
+	id string,
+	dependents []*dependent,
+	report *DeleteReport,
+) error {
+	if err := c.deleteDependents(ctx, definition, id, dependents, report); err != nil {
+		return err
+	}
+
+	filter := &pb.RelationshipFilter{
+		ResourceType:       definition,
+		OptionalResourceId: id,
+	}
+	for {
+		rels, err := c.readPage(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if len(rels) == 0 {
+			return nil
+		}
+		if err := c.deleteAll(ctx, definition, rels, report); err != nil {
+			return err
+		}
+	}
+}
+
+// deleteDependents deletes resources owned by the resource, in pages.
+func (c *Client) deleteDependents(
+	ctx context.Context,
+	definition string,
+	id string,
+	dependents []*dependent,
+	report *DeleteReport,
+) error {
+	for _, d := range dependents {
+		filter := &pb.RelationshipFilter{
+			ResourceType:     d.definition,
+			OptionalRelation: d.relation,
+			OptionalSubjectFilter: &pb.SubjectFilter{
+				SubjectType:       definition,
+				OptionalSubjectId: id,
+			},
+		}
+
+		// deleted resources are not read again, so the next page
+		// is always the first one
+		for {
+			links, err := c.readPage(ctx, filter)
+			if err != nil {
+				return err
+			}
+			if len(links) == 0 {
+				break
+			}
+
+			ids := make(map[string]bool, len(links))
+			for _, link := range links {
+				err := c.deleteDependents(ctx, d.definition, link.Resource.ObjectId, d.dependents, report)
+				if err != nil {
+					return err
+				}
+				ids[link.Resource.ObjectId] = true
+			}
+
+			rels, err := c.readResources(ctx, d.definition, ids)
+			if err != nil {
+				return err
+			}
+
+			var others []*pb.Relationship
+			for _, rel := range rels {
+				if rel.Relation != d.relation || rel.Subject.Object.ObjectType != definition {
+					others = append(others, rel)
+				}
+			}
+
+			if err := c.deleteAll(ctx, d.definition, others, report); err != nil {
+				return err
+			}
+			if err := c.deleteAll(ctx, d.definition, links, report); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readPage reads the first page of relationships matching the filter.
+// Reads are fully consistent, so deleted relationships are not read again.
+func (c *Client) readPage(ctx context.Context, filter *pb.RelationshipFilter) ([]*pb.Relationship, error) {
+	it := c.IterateRelationships(ctx, filter, FullyConsistent(), WithPageSize(cascadePageSize))
+	defer it.Close()
+
+	var rels []*pb.Relationship
+	for len(rels) < cascadePageSize && it.Next() {
+		rels = append(rels, it.Value())
+	}
+	return rels, it.Err()
+}
+
+// readResources reads all relationships of the resources of the definition
+// with a single filter, the resources are matched by id in memory.
+func (c *Client) readResources(
+	ctx context.Context,
+	definition string,
+	ids map[string]bool,
+) ([]*pb.Relationship, error) {
+	it := c.IterateRelationships(ctx, &pb.RelationshipFilter{ResourceType: definition}, FullyConsistent())
+	defer it.Close()
+
+	var rels []*pb.Relationship
+	for it.Next() {
+		if rel := it.Value(); ids[rel.Resource.ObjectId] {
+			rels = append(rels, rel)
+		}
+	}
+	return rels, it.Err()
+}
+
+func (c *Client) deleteAll(
+	ctx context.Context,
+	definition string,
+	rels []*pb.Relationship,
+	report *DeleteReport,
+) error {
+	updates := make([]*pb.RelationshipUpdate, len(rels))
+	for i, rel := range rels {
+		updates[i] = &pb.RelationshipUpdate{
+			Operation:    pb.RelationshipUpdate_OPERATION_DELETE,
+			Relationship: rel,
+		}
+	}
+
+	bulk, err := c.WriteRelationshipsBulk(ctx, updates)
+	report.Deleted[definition] += bulk.Written
+	if err != nil {
+		return fmt.Errorf("authz: delete %s relationships: %w", definition, err)
+	}
+	return nil
+}
@@ -2,27 +2,86 @@ package client
 
 import (
 	"context"
-	_ "embed"
+	"embed"
 	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
 
 	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	"github.com/authzed/authzed-go/v1"
-	"github.com/authzed/grpcutil"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"github.com/authzed/spicedb/pkg/tuple"
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-//go:embed schemas/v1.zed
-var schemaV1 string
+// SchemaVersion is the latest schema version,
+// the typed client is generated from it.
+const SchemaVersion = 2
 
+//go:embed schemas/*.zed
+var schemas embed.FS
+
+// Schema returns the embedded schema of the given version,
+// i.e. the content of schemas/v<version>.zed.
+func Schema(version int) (string, error) {
+	schema, err := schemas.ReadFile(fmt.Sprintf("schemas/v%d.zed", version))
+	if err != nil {
+		return "", fmt.Errorf("authz: schema version %d: %w", version, err)
+	}
+	return string(schema), nil
+}
+
+// ErrDenied is returned when the subject doesn't have the permission.
 type ErrDenied struct {
-	rel string
+	ResourceType string
+	ResourceID   string
+	Permission   string
+	SubjectType  string
+	SubjectID    string
+
+	// Conditional reports that the permission depends on caveat context
+	// which wasn't passed with the request, so it could not be decided.
+	// It's a bug in the caller rather than lack of access.
+	Conditional bool
+	// MissingFields are names of the missing caveat context fields
+	// of the conditional denial.
+	MissingFields []string
+
+	// Trace of the check, it's set only in builds with the authzdebug tag.
+	Trace *Trace
+}
+
+func newErrDenied(
+	resource *pb.ObjectReference,
+	permission string,
+	subject *pb.SubjectReference,
+) *ErrDenied {
+	return &ErrDenied{
+		ResourceType: resource.GetObjectType(),
+		ResourceID:   resource.GetObjectId(),
+		Permission:   permission,
+		SubjectType:  subject.GetObject().GetObjectType(),
+		SubjectID:    subject.GetObject().GetObjectId(),
+	}
 }
 
 func (e *ErrDenied) Error() string {
-	return "access denied: " + e.rel
+	rel := tuple.MustStringRelationship(&pb.Relationship{
+		Resource: objRef(e.ResourceType, e.ResourceID),
+		Relation: e.Permission,
+		Subject:  subRef(e.SubjectType, e.SubjectID),
+	})
+	msg := "access denied: " + rel
+	if e.Conditional {
+		msg += ": missing caveat context " + strings.Join(e.MissingFields, ", ")
+	}
+	if e.Trace != nil {
+		msg += "\n" + e.Trace.String()
+	}
+	return msg
 }
 
 func (e *ErrDenied) Is(target error) bool {
@@ -34,21 +93,48 @@ func IsDenied(err error) bool {
 	return errors.Is(err, &ErrDenied{})
 }
 
+// IsConditionalDenied reports if the permission could not be decided,
+// because the caveat context was missing.
+func IsConditionalDenied(err error) bool {
+	var denied *ErrDenied
+	return errors.As(err, &denied) && denied.Conditional
+}
+
 type Client struct {
 	c *authzed.ClientWithExperimental
+
+	// defaultConsistency of reads, if nil, then minimize latency is used.
+	defaultConsistency *pb.Consistency
+
+	// retryPolicy of idempotent requests.
+	retryPolicy RetryPolicy
+
+	// checkCache of permission checks, nil if disabled.
+	checkCache *checkCache
 }
 
+// New creates the client connected to the server without transport security.
+// Use NewWithOptions to configure TLS and other connection settings.
 func New(address string, secret string) (*Client, error) {
-	client, err := authzed.NewClientWithExperimentalAPIs(
-		address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpcutil.WithInsecureBearerToken(secret),
-	)
+	return NewWithOptions(address, WithInsecure(), WithBearerToken(secret))
+}
+
+// NewWithOptions creates the client connected to the server.
+// By default the connection is secured with TLS verified by system certificates.
+func NewWithOptions(address string, opts ...Option) (*Client, error) {
+	o, err := newOptions(opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{c: client}, nil
+	c := &Client{retryPolicy: o.retry, checkCache: o.newCheckCache()}
+	dialOpts := append(o.transportOptions(), o.callOptions()...)
+	dialOpts = append(dialOpts, c.zedTokenDialOptions()...)
+	c.c, err = authzed.NewClientWithExperimentalAPIs(address, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
 }
 
 // UNSAFE_GetClient is a temporary method to get the underlying client
@@ -57,15 +143,31 @@ func (c *Client) UNSAFE_GetClient() *authzed.ClientWithExperimental {
 	return c.c
 }
 
-func (c *Client) MigrateSchema(ctx context.Context) error {
-	req := &pb.WriteSchemaRequest{Schema: schemaV1}
+// ReadSchema returns the schema written to the server.
+// It returns an empty schema if no schema has been written yet.
+func (c *Client) ReadSchema(ctx context.Context) (string, error) {
+	resp, err := c.c.ReadSchema(ctx, &pb.ReadSchemaRequest{})
+	if status.Code(err) == codes.NotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("authz: read schema: %w", err)
+	}
+	return resp.SchemaText, nil
+}
+
+// WriteSchema writes the schema to the server.
+// NOTE: it doesn't check if the schema change is safe,
+// use the migrate package to change the schema of the running server.
+func (c *Client) WriteSchema(ctx context.Context, schema string) error {
+	req := &pb.WriteSchemaRequest{Schema: schema}
 	if _, err := c.c.WriteSchema(ctx, req); err != nil {
-		return err
+		return fmt.Errorf("authz: write schema: %w", err)
 	}
 	return nil
 }
 
-func (c *Client) writeRelationship(ctx context.Context, rel *pb.Relationship) error {
+func (c *Client) writeRelationship(ctx context.Context, rel *pb.Relationship) (*pb.ZedToken, error) {
 	req := &pb.WriteRelationshipsRequest{
 		Updates: []*pb.RelationshipUpdate{
 			{
@@ -75,13 +177,21 @@ func (c *Client) writeRelationship(ctx context.Context, rel *pb.Relationship) er
 		},
 	}
 
-	if _, err := c.c.WriteRelationships(ctx, req); err != nil {
-		return fmt.Errorf("authz: write relationships %q: %w", relstr(rel), err)
+	// touch and delete of a single relationship are idempotent
+	var resp *pb.WriteRelationshipsResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.c.WriteRelationships(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("authz: write relationships %q: %w", relstr(rel), err)
 	}
-	return nil
+
+	setZedToken(ctx, resp.WrittenAt)
+	return resp.WrittenAt, nil
 }
 
-func (c *Client) deleteRelationship(ctx context.Context, rel *pb.Relationship) error {
+func (c *Client) deleteRelationship(ctx context.Context, rel *pb.Relationship) (*pb.ZedToken, error) {
 	req := &pb.WriteRelationshipsRequest{
 		Updates: []*pb.RelationshipUpdate{
 			{
@@ -91,67 +201,203 @@ func (c *Client) deleteRelationship(ctx context.Context, rel *pb.Relationship) e
 		},
 	}
 
-	if _, err := c.c.WriteRelationships(ctx, req); err != nil {
-		return fmt.Errorf("authz: delete relationships %q: %w", relstr(rel), err)
+	// touch and delete of a single relationship are idempotent
+	var resp *pb.WriteRelationshipsResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.c.WriteRelationships(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("authz: delete relationships %q: %w", relstr(rel), err)
 	}
-	return nil
+
+	setZedToken(ctx, resp.WrittenAt)
+	return resp.WrittenAt, nil
 }
 
 func (c *Client) checkPermission(ctx context.Context, req *pb.CheckPermissionRequest) error {
-	resp, err := c.c.CheckPermission(ctx, req)
-	if err != nil {
-		return fmt.Errorf("authz: check permission %q: %w", relstr(req), err)
+	if c.checkCache == nil || debugTraces {
+		_, err := c.tracePermission(ctx, req, debugTraces)
+		return err
 	}
+	return c.checkCache.check(req, func() (*pb.CheckPermissionResponse, error) {
+		return c.check(ctx, req)
+	})
+}
 
-	if resp.Permissionship != pb.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION {
-		return &ErrDenied{relstr(req)}
+// tracePermission checks the permission like checkPermission.
+// With tracing it also returns the trace of the check,
+// which is attached to the returned ErrDenied too.
+// The check cache is not used.
+func (c *Client) tracePermission(
+	ctx context.Context,
+	req *pb.CheckPermissionRequest,
+	tracing bool,
+) (*Trace, error) {
+	req.WithTracing = tracing
+	resp, err := c.check(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	trace := newTrace(resp.GetDebugTrace().GetCheck())
+	if err := permissionship(req, resp); err != nil {
+		err.Trace = trace
+		return trace, err
+	}
+	return trace, nil
 }
 
-func (c *Client) lookupResources(ctx context.Context, req *pb.LookupResourcesRequest) ([]string, error) {
-	stream, err := c.c.LookupResources(ctx, req)
+func (c *Client) check(ctx context.Context, req *pb.CheckPermissionRequest) (*pb.CheckPermissionResponse, error) {
+	var resp *pb.CheckPermissionResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.c.CheckPermission(ctx, req)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("authz: lookup resources %q: %w", relstr(req), err)
+		return nil, fmt.Errorf("authz: check permission %q: %w", relstr(req), err)
+	}
+	return resp, nil
+}
+
+// permissionship returns ErrDenied if the response doesn't grant the permission.
+func permissionship(req *pb.CheckPermissionRequest, resp *pb.CheckPermissionResponse) *ErrDenied {
+	switch resp.Permissionship {
+	case pb.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION:
+		return nil
+	case pb.CheckPermissionResponse_PERMISSIONSHIP_CONDITIONAL_PERMISSION:
+		err := newErrDenied(req.Resource, req.Permission, req.Subject)
+		err.Conditional = true
+		// the same field is reported for every caveat on the path
+		fields := slices.Clone(resp.PartialCaveatInfo.GetMissingRequiredContext())
+		slices.Sort(fields)
+		err.MissingFields = slices.Compact(fields)
+		return err
+	default:
+		return newErrDenied(req.Resource, req.Permission, req.Subject)
 	}
+}
+
+func (c *Client) lookupResources(ctx context.Context, req *pb.LookupResourcesRequest) ([]string, error) {
+	it := c.iterateResources(ctx, req, &readOptions{})
+	defer it.Close()
 
 	var ids []string
-	for {
-		resp, err := stream.Recv()
-		switch {
-		case errors.Is(err, io.EOF):
-			return ids, nil
-		case err != nil:
-			return nil, err
-		default:
-			ids = append(ids, resp.ResourceObjectId)
+	for it.Next() {
+		ids = append(ids, it.Value())
+	}
+	return ids, it.Err()
+}
+
+// Subject is a subject found by the subject lookup.
+type Subject struct {
+	ID string
+
+	// Conditional reports that the permission depends on caveat context
+	// which wasn't passed with the lookup, see WithCaveatContext.
+	// Such subjects may or may not have the permission.
+	Conditional bool
+	// MissingFields are names of the missing caveat context fields.
+	MissingFields []string
+}
+
+func (c *Client) lookupSubjects(
+	ctx context.Context,
+	req *pb.LookupSubjectsRequest,
+	fn func(Subject) error,
+) error {
+	var errFn error
+	err := c.retry(ctx, func() error {
+		stream, err := c.c.LookupSubjects(ctx, req)
+		if err != nil {
+			return err
 		}
+
+		received := false
+		for {
+			resp, err := stream.Recv()
+			switch {
+			case errors.Is(err, io.EOF):
+				return nil
+			case err != nil && received:
+				// subjects have been passed to fn already
+				return backoff.Permanent(err)
+			case err != nil:
+				return err
+			}
+
+			received = true
+			resolved := resp.GetSubject()
+			subject := Subject{ID: resolved.GetSubjectObjectId()}
+			if resolved.GetPermissionship() == pb.LookupPermissionship_LOOKUP_PERMISSIONSHIP_CONDITIONAL_PERMISSION {
+				subject.Conditional = true
+				subject.MissingFields = resolved.GetPartialCaveatInfo().GetMissingRequiredContext()
+			}
+
+			if errFn = fn(subject); errFn != nil {
+				return backoff.Permanent(errFn)
+			}
+		}
+	})
+	if errFn != nil {
+		return errFn
 	}
+	if err != nil {
+		return fmt.Errorf("authz: lookup subjects %q: %w", relstr(req), err)
+	}
+	return nil
 }
 
+// ReadRelationships returns all relationships matching the filter.
+// Use IterateRelationships to read many relationships.
 func (c *Client) ReadRelationships(
 	ctx context.Context,
 	req *pb.RelationshipFilter,
+	opts ...ReadOption,
 ) ([]*pb.Relationship, error) {
-	stream, err := c.c.ReadRelationships(ctx, &pb.ReadRelationshipsRequest{
-		Consistency:        fullConsistency(),
+	it := c.IterateRelationships(ctx, req, opts...)
+	defer it.Close()
+
+	var rels []*pb.Relationship
+	for it.Next() {
+		rels = append(rels, it.Value())
+	}
+	return rels, it.Err()
+}
+
+// HasRelationships reports if there's any relationship matching the filter.
+func (c *Client) HasRelationships(
+	ctx context.Context,
+	req *pb.RelationshipFilter,
+	opts ...ReadOption,
+) (bool, error) {
+	read := &pb.ReadRelationshipsRequest{
+		Consistency:        c.consistency(ctx, opts...),
 		RelationshipFilter: req,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("authz: read relationships: %w", err)
+		OptionalLimit:      1,
 	}
 
-	var rels []*pb.Relationship
-	for {
-		resp, err := stream.Recv()
+	var found bool
+	err := c.retry(ctx, func() error {
+		stream, err := c.c.ReadRelationships(ctx, read)
+		if err != nil {
+			return err
+		}
+
+		_, err = stream.Recv()
 		switch {
 		case errors.Is(err, io.EOF):
-			return rels, nil
+			found = false
+			return nil
 		case err != nil:
-			return nil, err
+			return err
 		default:
-			rels = append(rels, resp.Relationship)
+			found = true
+			return nil
 		}
+	})
+	if err != nil {
+		return false, fmt.Errorf("authz: read relationships: %w", err)
 	}
+	return found, nil
 }
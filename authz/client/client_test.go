@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+func TestErrDenied(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	memberId := "alice"
+	offDayId := "offday"
+
+	_, err = tclient.WriteOrganizationAdmin(ctx, orgId, memberId)
+	assert.NoError(t, err)
+	_, err = tclient.WriteOffDayOrganization(ctx, offDayId, orgId)
+	assert.NoError(t, err)
+
+	t.Run("denied", func(t *testing.T) {
+		err := tclient.CanEditOffDay(ctx, offDayId, MemberPrincipal("bob"))
+		assert.True(t, IsDenied(err))
+		assert.True(t, !IsConditionalDenied(err))
+
+		var denied *ErrDenied
+		assert.True(t, errors.As(err, &denied))
+		// trace is set in authzdebug builds only
+		denied.Trace = nil
+		assert.Equal(t, denied, &ErrDenied{
+			ResourceType: definitionOffDay,
+			ResourceID:   offDayId,
+			Permission:   permissionEdit,
+			SubjectType:  definitionMember,
+			SubjectID:    "bob",
+		})
+		assert.Equal(t, err.Error(), "access denied: offday:offday#edit@member:bob")
+	})
+
+	t.Run("conditional", func(t *testing.T) {
+		// products required by the permission are not passed
+		err := tclient.checkPermission(ctx, &pb.CheckPermissionRequest{
+			Resource:    objRef(definitionOffDay, offDayId),
+			Permission:  permissionEdit,
+			Subject:     subRef(definitionMember, memberId),
+			Consistency: fullConsistency(),
+		})
+		assert.True(t, IsDenied(err))
+		assert.True(t, IsConditionalDenied(err))
+
+		var denied *ErrDenied
+		assert.True(t, errors.As(err, &denied))
+		assert.Equal(t, denied.MissingFields, []string{caveatProductsOneOfArg, caveatProductsRequiredArg})
+		assert.ErrorContains(t, err, "missing caveat context")
+	})
+
+	t.Run("get", func(t *testing.T) {
+		_, err := tclient.GetOrganization(ctx, orgId, MemberPrincipal("bob"))
+
+		var denied *ErrDenied
+		assert.True(t, errors.As(err, &denied))
+		assert.Equal(t, denied, &ErrDenied{
+			ResourceType: definitionOrganization,
+			ResourceID:   orgId,
+			Permission:   permissionAccess,
+			SubjectType:  definitionMember,
+			SubjectID:    "bob",
+		})
+	})
+}
+
+func TestLookupSubjects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	adminId := "alice"
+	sequencesId := "bob"
+
+	_, err = tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
+	assert.NoError(t, err)
+	_, err = tclient.WriteOrganizationSDR(ctx, orgId, sequencesId, ProductSequences)
+	assert.NoError(t, err)
+
+	t.Run("products", func(t *testing.T) {
+		var ids []string
+		err := tclient.LookupCreateOrganizationSequenceMembers(ctx, orgId, collectSubjects(&ids))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{sequencesId})
+	})
+
+	t.Run("caveat_context", func(t *testing.T) {
+		// no product is required
+		var ids []string
+		err := tclient.LookupCreateOrganizationSequenceMembers(ctx, orgId, collectSubjects(&ids),
+			WithCaveatContext(map[string]any{caveatProductsRequiredArg: []any{}}),
+		)
+		assert.NoError(t, err)
+		sort.Strings(ids)
+		assert.Equal(t, ids, []string{adminId, sequencesId})
+	})
+
+	t.Run("conditional", func(t *testing.T) {
+		// products required by the relation are not passed
+		var subjects []Subject
+		err := tclient.lookupSubjects(ctx, &pb.LookupSubjectsRequest{
+			Resource:          objRef(definitionOrganization, orgId),
+			Permission:        relationSDR,
+			SubjectObjectType: definitionMember,
+			Consistency:       fullConsistency(),
+		}, func(s Subject) error {
+			subjects = append(subjects, s)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Len(t, subjects, 1)
+		assert.Equal(t, subjects[0].ID, sequencesId)
+		assert.True(t, subjects[0].Conditional)
+		assert.True(t, len(subjects[0].MissingFields) > 0)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		errStop := errors.New("stop")
+
+		calls := 0
+		err := tclient.LookupAccessOrganizationMembers(ctx, orgId, func(Subject) error {
+			calls++
+			return errStop
+		})
+		assert.Equal(t, err, errStop)
+		assert.Equal(t, calls, 1)
+	})
+}
+
+// collectSubjects appends ids of the looked up subjects to ids.
+func collectSubjects(ids *[]string) func(Subject) error {
+	return func(s Subject) error {
+		*ids = append(*ids, s.ID)
+		return nil
+	}
+}
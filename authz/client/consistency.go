@@ -0,0 +1,314 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ReadOption configures consistency of checks, lookups and reads.
+//
+// Consistency is resolved in the following order:
+//   - the read option, if given,
+//   - at least as fresh as the last write token carried by the context,
+//   - the client default, minimize latency unless the client is a test client.
+type ReadOption func(*readOptions)
+
+type readOptions struct {
+	consistency   *pb.Consistency
+	caveatContext map[string]any
+	cursor        *pb.Cursor
+	pageSize      uint32
+}
+
+func newReadOptions(opts ...ReadOption) *readOptions {
+	o := &readOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// MinimizeLatency reads from the cache, if possible.
+// The result may be stale up to the spicedb quantization window.
+func MinimizeLatency() ReadOption {
+	return func(o *readOptions) {
+		o.consistency = minimizeLatency()
+	}
+}
+
+// AtLeastAsFresh reads data at least as fresh as the given write token.
+func AtLeastAsFresh(token *pb.ZedToken) ReadOption {
+	return func(o *readOptions) {
+		o.consistency = atLeastAsFresh(token)
+	}
+}
+
+// FullyConsistent reads the most recent data, bypassing the cache.
+// NOTE: it is the most expensive option, use it with caution.
+func FullyConsistent() ReadOption {
+	return func(o *readOptions) {
+		o.consistency = fullConsistency()
+	}
+}
+
+// WithCaveatContext passes caveat context to checks and lookups.
+// It's merged with the products required by the permission,
+// which are always passed. Values must be convertible to structpb.Value.
+func WithCaveatContext(caveatContext map[string]any) ReadOption {
+	return func(o *readOptions) {
+		if o.caveatContext == nil {
+			o.caveatContext = make(map[string]any, len(caveatContext))
+		}
+		for k, v := range caveatContext {
+			o.caveatContext[k] = v
+		}
+	}
+}
+
+// WithCursor resumes the iteration after the result of the cursor,
+// see Iterator.Cursor. An empty cursor starts from the beginning.
+func WithCursor(cursor string) ReadOption {
+	return func(o *readOptions) {
+		if cursor != "" {
+			o.cursor = &pb.Cursor{Token: cursor}
+		}
+	}
+}
+
+// WithPageSize sets the number of results read from spicedb at once
+// by iterators, by default all results are read in a single page.
+func WithPageSize(size uint32) ReadOption {
+	return func(o *readOptions) {
+		o.pageSize = size
+	}
+}
+
+// consistency resolves consistency of a read request.
+func (c *Client) consistency(ctx context.Context, opts ...ReadOption) *pb.Consistency {
+	o := newReadOptions(opts...)
+	if o.consistency != nil {
+		return o.consistency
+	}
+	if token := ZedTokenFromContext(ctx); token != nil {
+		return atLeastAsFresh(token)
+	}
+	if c.defaultConsistency != nil {
+		return c.defaultConsistency
+	}
+	return minimizeLatency()
+}
+
+type zedTokenKey struct{}
+
+// zedTokenHolder holds the last write token of a request.
+// It's shared by all contexts derived from the request context,
+// so writes made deep in the call stack are visible to later reads.
+type zedTokenHolder struct {
+	mx    sync.Mutex
+	token *pb.ZedToken
+	// rejected is the token dropped after the server rejected it,
+	// see Client.rejectZedToken.
+	rejected string
+}
+
+// WithZedToken returns a copy of ctx which carries the last write token
+// through a request, e.g. the token of the previous request of the user.
+// Writes made with the returned context replace the token, and reads
+// are at least as fresh as it, which gives read-your-writes consistency.
+// The token may be nil.
+//
+// A token rejected by the server, e.g. a forged one or a token of a reset
+// datastore, is dropped and the read is retried with the default consistency,
+// so ZedTokenFromContext returns nil after it.
+func WithZedToken(ctx context.Context, token *pb.ZedToken) context.Context {
+	return context.WithValue(ctx, zedTokenKey{}, &zedTokenHolder{token: token})
+}
+
+// ZedTokenFromContext returns the last write token carried by ctx.
+// It returns nil if there's no token.
+func ZedTokenFromContext(ctx context.Context) *pb.ZedToken {
+	holder, ok := ctx.Value(zedTokenKey{}).(*zedTokenHolder)
+	if !ok {
+		return nil
+	}
+
+	holder.mx.Lock()
+	defer holder.mx.Unlock()
+	return holder.token
+}
+
+// setZedToken replaces the last write token carried by ctx, if any.
+func setZedToken(ctx context.Context, token *pb.ZedToken) {
+	holder, ok := ctx.Value(zedTokenKey{}).(*zedTokenHolder)
+	if !ok || token == nil {
+		return
+	}
+
+	holder.mx.Lock()
+	holder.token = token
+	holder.mx.Unlock()
+}
+
+// rejectZedToken drops the token carried by ctx if the server rejected it
+// in the consistency of the read. It returns the consistency
+// the read should be retried with.
+func (c *Client) rejectZedToken(
+	ctx context.Context,
+	consistency *pb.Consistency,
+	err error,
+) (*pb.Consistency, bool) {
+	token := consistency.GetAtLeastAsFresh()
+	holder, ok := ctx.Value(zedTokenKey{}).(*zedTokenHolder)
+	if token == nil || !ok || !invalidZedToken(err) {
+		return nil, false
+	}
+
+	holder.mx.Lock()
+	switch token.GetToken() {
+	case holder.token.GetToken():
+		holder.token = nil
+		holder.rejected = token.GetToken()
+	case holder.rejected:
+		// reads which resolved the consistency before the token was dropped
+	default:
+		// the token was passed with AtLeastAsFresh, the error is returned
+		holder.mx.Unlock()
+		return nil, false
+	}
+	holder.mx.Unlock()
+
+	return c.consistency(ctx), true
+}
+
+// invalidZedToken reports if the server rejected the token of the read,
+// other invalid arguments of the read are not matched.
+// Spicedb doesn't report a reason for it, so it's matched by the message:
+// malformed tokens are reported with codes.Unknown and tokens
+// of unknown revisions with codes.OutOfRange.
+func invalidZedToken(err error) bool {
+	s, _ := status.FromError(err)
+	switch s.Code() {
+	case codes.Unknown, codes.InvalidArgument:
+		return strings.Contains(s.Message(), "invalid revision requested")
+	case codes.OutOfRange:
+		return strings.Contains(s.Message(), "invalid zedtoken")
+	default:
+		return false
+	}
+}
+
+// retryRequest returns a copy of the read request with the consistency
+// to retry it with, if the server rejected the token carried by ctx.
+func (c *Client) retryRequest(ctx context.Context, req any, err error) (any, bool) {
+	r, ok := req.(interface{ GetConsistency() *pb.Consistency })
+	if !ok {
+		return nil, false
+	}
+	consistency, ok := c.rejectZedToken(ctx, r.GetConsistency(), err)
+	if !ok {
+		return nil, false
+	}
+
+	retry := proto.Clone(req.(proto.Message)).ProtoReflect()
+	field := retry.Descriptor().Fields().ByName("consistency")
+	retry.Set(field, protoreflect.ValueOfMessage(consistency.ProtoReflect()))
+	return retry.Interface(), true
+}
+
+// zedTokenDialOptions retry reads rejected because of the token
+// carried by the context, see WithZedToken.
+func (c *Client) zedTokenDialOptions() []grpc.DialOption {
+	unary := func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if retry, ok := c.retryRequest(ctx, req, err); ok {
+			return invoker(ctx, method, retry, reply, cc, opts...)
+		}
+		return err
+	}
+
+	stream := func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		s, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil || desc.ClientStreams {
+			return s, err
+		}
+		return &zedTokenStream{
+			ClientStream: s,
+			c:            c,
+			ctx:          ctx,
+			reopen: func() (grpc.ClientStream, error) {
+				return streamer(ctx, desc, cc, method, opts...)
+			},
+		}, nil
+	}
+
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unary),
+		grpc.WithChainStreamInterceptor(stream),
+	}
+}
+
+// zedTokenStream reopens a server stream, if the server rejected
+// the token carried by the context. The token is checked before
+// the first response, so only the first receive may fail because of it.
+type zedTokenStream struct {
+	grpc.ClientStream
+
+	c        *Client
+	ctx      context.Context
+	reopen   func() (grpc.ClientStream, error)
+	req      any
+	received bool
+}
+
+func (s *zedTokenStream) SendMsg(m any) error {
+	s.req = m
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *zedTokenStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if s.received {
+		return err
+	}
+	s.received = true
+
+	retry, ok := s.c.retryRequest(s.ctx, s.req, err)
+	if !ok {
+		return err
+	}
+
+	stream, err := s.reopen()
+	if err != nil {
+		return err
+	}
+	s.ClientStream = stream
+	if err := stream.SendMsg(retry); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	return stream.RecvMsg(m)
+}
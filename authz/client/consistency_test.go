@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestConsistency(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("resolve", func(t *testing.T) {
+		token := &pb.ZedToken{Token: "token"}
+		c := &Client{}
+
+		assert.True(t, proto.Equal(c.consistency(ctx), minimizeLatency()))
+		assert.True(t, proto.Equal(c.consistency(ctx, FullyConsistent()), fullConsistency()))
+		assert.True(t, proto.Equal(c.consistency(ctx, AtLeastAsFresh(token)), atLeastAsFresh(token)))
+
+		tctx := WithZedToken(ctx, token)
+		assert.True(t, proto.Equal(c.consistency(tctx), atLeastAsFresh(token)))
+		assert.True(t, proto.Equal(c.consistency(tctx, MinimizeLatency()), minimizeLatency()))
+
+		// empty token is ignored
+		assert.True(t, proto.Equal(c.consistency(WithZedToken(ctx, nil)), minimizeLatency()))
+
+		c = &Client{defaultConsistency: fullConsistency()}
+		assert.True(t, proto.Equal(c.consistency(ctx), fullConsistency()))
+	})
+
+	t.Run("read_your_writes", func(t *testing.T) {
+		tclient, err := StartTestServer(ctx)
+		assert.NoError(t, err)
+
+		orgId := "rift"
+		memberId := "alice"
+		offDayId := "offday"
+
+		rctx := WithZedToken(ctx, nil)
+		assert.Nil(t, ZedTokenFromContext(rctx))
+
+		token, err := tclient.WriteOrganizationAdmin(rctx, orgId, memberId)
+		assert.NoError(t, err)
+		assert.True(t, token != nil)
+		assert.Equal(t, ZedTokenFromContext(rctx), token)
+
+		token, err = tclient.WriteOffDayOrganization(rctx, offDayId, orgId)
+		assert.NoError(t, err)
+		assert.Equal(t, ZedTokenFromContext(rctx), token)
+
+		err = tclient.CanEditOffDay(rctx, offDayId, MemberPrincipal(memberId))
+		assert.NoError(t, err)
+
+		ids, err := tclient.ListEditOffDays(ctx, MemberPrincipal(memberId), AtLeastAsFresh(token))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{offDayId})
+
+		token, err = tclient.DeleteOffDayOrganization(rctx, offDayId, orgId)
+		assert.NoError(t, err)
+		assert.Equal(t, ZedTokenFromContext(rctx), token)
+
+		err = tclient.CanEditOffDay(rctx, offDayId, MemberPrincipal(memberId))
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		// writes without token holder don't fail
+		_, err = tclient.WriteOffDayOrganization(ctx, offDayId, orgId)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejected_token", func(t *testing.T) {
+		tclient, err := StartTestServer(ctx)
+		assert.NoError(t, err)
+
+		orgId := "rift"
+		memberId := "alice"
+		offDayId := "offday"
+
+		_, err = tclient.WriteOrganizationAdmin(ctx, orgId, memberId)
+		assert.NoError(t, err)
+		token, err := tclient.WriteOffDayOrganization(ctx, offDayId, orgId)
+		assert.NoError(t, err)
+
+		// e.g. a forged token or a token of a reset datastore
+		garbage := &pb.ZedToken{Token: "garbage"}
+
+		rctx := WithZedToken(ctx, garbage)
+		err = tclient.CanEditOffDay(rctx, offDayId, MemberPrincipal(memberId))
+		assert.NoError(t, err)
+		assert.Nil(t, ZedTokenFromContext(rctx))
+
+		// streams are reopened
+		rctx = WithZedToken(ctx, garbage)
+		ids, err := tclient.ListEditOffDays(rctx, MemberPrincipal(memberId))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{offDayId})
+		assert.Nil(t, ZedTokenFromContext(rctx))
+
+		// tokens passed explicitly are not dropped
+		_, err = tclient.ListEditOffDays(ctx, MemberPrincipal(memberId), AtLeastAsFresh(garbage))
+		assert.ErrorContains(t, err, "invalid revision")
+
+		// other invalid arguments keep the token, e.g. a too large page
+		rctx = WithZedToken(ctx, token)
+		it := tclient.IterateEditOffDays(rctx, MemberPrincipal(memberId), WithPageSize(5000))
+		for it.Next() {
+		}
+		assert.ErrorContains(t, it.Err(), "InvalidArgument")
+		assert.Equal(t, ZedTokenFromContext(rctx), token)
+	})
+}
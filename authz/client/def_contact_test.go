@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"rift/assert"
+)
+
+func TestContact(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	apiKey := "key"
+	adminId := "alice"
+	ownerId := "bob"
+	sdrId := "carol"
+	contactId := "contact"
+
+	t.Run("relations", func(t *testing.T) {
+		t.Run("relation_apikey", func(t *testing.T) {
+			_, err := tclient.WriteOrganizationApiKey(ctx, orgId, apiKey)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_sdr", func(t *testing.T) {
+			_, err := tclient.WriteOrganizationSDR(ctx, orgId, ownerId)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationSDR(ctx, orgId, sdrId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_admin", func(t *testing.T) {
+			_, err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_organization", func(t *testing.T) {
+			_, err := tclient.WriteContactOrganization(ctx, contactId, orgId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_owner", func(t *testing.T) {
+			_, err := tclient.WriteContactOwner(ctx, contactId, ownerId)
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("permissions", func(t *testing.T) {
+		t.Run("edit", func(t *testing.T) {
+			err := tclient.CanEditContact(ctx, contactId, MemberPrincipal(sdrId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			err = tclient.CanEditContact(ctx, contactId, MemberPrincipal(ownerId))
+			assert.NoError(t, err)
+
+			err = tclient.CanEditContact(ctx, contactId, MemberPrincipal(adminId))
+			assert.NoError(t, err)
+
+			ids, err := tclient.ListEditContacts(ctx, MemberPrincipal(ownerId))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{contactId})
+		})
+
+		t.Run("view", func(t *testing.T) {
+			err := tclient.CanViewContact(ctx, contactId, MemberPrincipal(sdrId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			ids, err := tclient.ListViewContacts(ctx, MemberPrincipal(sdrId))
+			assert.NoError(t, err)
+			assert.Len(t, ids, 0)
+
+			err = tclient.CanViewContact(ctx, contactId, MemberPrincipal(ownerId))
+			assert.NoError(t, err)
+
+			err = tclient.CanViewContact(ctx, contactId, MemberPrincipal(adminId))
+			assert.NoError(t, err)
+
+			ids, err = tclient.ListViewContacts(ctx, MemberPrincipal(adminId))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{contactId})
+		})
+
+		t.Run("view_apikey", func(t *testing.T) {
+			err := tclient.CanViewContact(ctx, contactId, ApiKeyPrincipal(apiKey))
+			assert.NoError(t, err)
+
+			err = tclient.CanViewContact(ctx, contactId, ApiKeyPrincipal("other"))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			ids, err := tclient.ListViewContacts(ctx, ApiKeyPrincipal(apiKey))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{contactId})
+		})
+
+		t.Run("delete", func(t *testing.T) {
+			err := tclient.CanDeleteContact(ctx, contactId, MemberPrincipal(sdrId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			err = tclient.CanDeleteContact(ctx, contactId, MemberPrincipal(ownerId))
+			assert.NoError(t, err)
+
+			err = tclient.CanDeleteContact(ctx, contactId, MemberPrincipal(adminId))
+			assert.NoError(t, err)
+
+			ids, err := tclient.ListDeleteContacts(ctx, MemberPrincipal(adminId))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{contactId})
+		})
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		contacts, err := tclient.ListContacts(ctx, MemberPrincipal(sdrId))
+		assert.NoError(t, err)
+		assert.Nil(t, contacts)
+
+		contacts, err = tclient.ListContacts(ctx, MemberPrincipal(ownerId))
+		assert.NoError(t, err)
+		assert.Equal(t, contacts, map[string]*Contact{
+			contactId: {Id: contactId, View: true, Edit: true, Delete: true},
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := tclient.DeleteContactOwner(ctx, contactId, ownerId)
+		assert.NoError(t, err)
+
+		contacts, err := tclient.ListContacts(ctx, MemberPrincipal(ownerId))
+		assert.NoError(t, err)
+		assert.Nil(t, contacts)
+
+		_, err = tclient.DeleteContactOrganization(ctx, contactId, orgId)
+		assert.NoError(t, err)
+
+		contacts, err = tclient.ListContacts(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Nil(t, contacts)
+	})
+}
@@ -22,58 +22,93 @@ func TestHoliday(t *testing.T) {
 
 	t.Run("organization", func(t *testing.T) {
 		t.Run("relation_apikey", func(t *testing.T) {
-			err := tclient.WriteOrganizationApiKey(ctx, orgId, apiKey)
+			_, err := tclient.WriteOrganizationApiKey(ctx, orgId, apiKey)
 			assert.NoError(t, err)
 		})
 
 		t.Run("relation_sdr", func(t *testing.T) {
-			err := tclient.WriteOrganizationSDR(ctx, orgId, sdrId)
+			_, err := tclient.WriteOrganizationSDR(ctx, orgId, sdrId)
 			assert.NoError(t, err)
 		})
 
 		t.Run("relation_admin", func(t *testing.T) {
-			err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
+			_, err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
 			assert.NoError(t, err)
 		})
 	})
 
 	t.Run("holiday", func(t *testing.T) {
 		t.Run("relation_organization", func(t *testing.T) {
-			err := tclient.WriteHolidayOrganization(ctx, holidayId, orgId)
+			_, err := tclient.WriteHolidayOrganization(ctx, holidayId, orgId)
 			assert.NoError(t, err)
 		})
 
 		t.Run("edit", func(t *testing.T) {
-			err := tclient.CanEditHoliday(ctx, holidayId, apiKey)
+			err := tclient.CanEditHoliday(ctx, holidayId, MemberPrincipal(apiKey))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanEditHoliday(ctx, holidayId, sdrId)
+			err = tclient.CanEditHoliday(ctx, holidayId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanEditHoliday(ctx, holidayId, adminId)
+			err = tclient.CanEditHoliday(ctx, holidayId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("view", func(t *testing.T) {
-			err := tclient.CanViewHoliday(ctx, holidayId, apiKey)
+			err := tclient.CanViewHoliday(ctx, holidayId, MemberPrincipal(apiKey))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanViewHoliday(ctx, holidayId, sdrId)
+			err = tclient.CanViewHoliday(ctx, holidayId, MemberPrincipal(sdrId))
 			assert.NoError(t, err)
 
-			err = tclient.CanViewHoliday(ctx, holidayId, adminId)
+			err = tclient.CanViewHoliday(ctx, holidayId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("delete", func(t *testing.T) {
-			err := tclient.CanDeleteHoliday(ctx, holidayId, apiKey)
+			err := tclient.CanDeleteHoliday(ctx, holidayId, MemberPrincipal(apiKey))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanDeleteHoliday(ctx, holidayId, sdrId)
+			err = tclient.CanDeleteHoliday(ctx, holidayId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanDeleteHoliday(ctx, holidayId, adminId)
+			err = tclient.CanDeleteHoliday(ctx, holidayId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 	})
+
+	t.Run("lookup", func(t *testing.T) {
+		ids, err := tclient.ListViewHolidays(ctx, MemberPrincipal(sdrId))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{holidayId})
+
+		ids, err = tclient.ListEditHolidays(ctx, MemberPrincipal(sdrId))
+		assert.NoError(t, err)
+		assert.Len(t, ids, 0)
+
+		items, err := tclient.ListHolidays(ctx, MemberPrincipal(sdrId))
+		assert.NoError(t, err)
+		assert.Equal(t, items, map[string]*Holiday{
+			holidayId: {Id: holidayId, View: true, Edit: false, Delete: false},
+		})
+
+		ids, err = tclient.ListDeleteHolidays(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{holidayId})
+
+		items, err = tclient.ListHolidays(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Equal(t, items, map[string]*Holiday{
+			holidayId: {Id: holidayId, View: true, Edit: true, Delete: true},
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := tclient.DeleteHolidayOrganization(ctx, holidayId, orgId)
+		assert.NoError(t, err)
+
+		items, err := tclient.ListHolidays(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Nil(t, items)
+	})
 }
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"rift/assert"
+)
+
+func TestInbox(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	apiKey := "key"
+	adminWarmerId := "alice"
+	adminSequencesId := "erin"
+	adminNoProductsId := "dave"
+	ownerId := "bob"
+	inboxId := "inbox"
+
+	t.Run("relations", func(t *testing.T) {
+		t.Run("organization", func(t *testing.T) {
+			_, err := tclient.WriteOrganizationApiKey(ctx, orgId, apiKey)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationAdmin(ctx, orgId, adminWarmerId, ProductWarmer)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationAdmin(ctx, orgId, adminSequencesId, ProductSequences)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationAdmin(ctx, orgId, adminNoProductsId, ProductMeetings)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationSDR(ctx, orgId, ownerId, ProductSequences)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_organization", func(t *testing.T) {
+			_, err := tclient.WriteInboxOrganization(ctx, inboxId, orgId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_owner", func(t *testing.T) {
+			_, err := tclient.WriteInboxOwner(ctx, inboxId, ownerId)
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("permissions", func(t *testing.T) {
+		t.Run("edit", func(t *testing.T) {
+			// sequences or warmer is enough
+			err := tclient.CanEditInbox(ctx, inboxId, MemberPrincipal(adminWarmerId))
+			assert.NoError(t, err)
+
+			err = tclient.CanEditInbox(ctx, inboxId, MemberPrincipal(adminSequencesId))
+			assert.NoError(t, err)
+
+			err = tclient.CanEditInbox(ctx, inboxId, MemberPrincipal(adminNoProductsId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			// owner has no permissions on inbox
+			err = tclient.CanEditInbox(ctx, inboxId, MemberPrincipal(ownerId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			ids, err := tclient.ListEditInboxes(ctx, MemberPrincipal(adminWarmerId))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{inboxId})
+		})
+
+		t.Run("view", func(t *testing.T) {
+			err := tclient.CanViewInbox(ctx, inboxId, MemberPrincipal(adminSequencesId))
+			assert.NoError(t, err)
+
+			err = tclient.CanViewInbox(ctx, inboxId, MemberPrincipal(ownerId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			ids, err := tclient.ListViewInboxes(ctx, MemberPrincipal(adminNoProductsId))
+			assert.NoError(t, err)
+			assert.Len(t, ids, 0)
+		})
+
+		t.Run("view_apikey", func(t *testing.T) {
+			err := tclient.CanViewInbox(ctx, inboxId, ApiKeyPrincipal(apiKey))
+			assert.NoError(t, err)
+
+			err = tclient.CanViewInbox(ctx, inboxId, ApiKeyPrincipal("other"))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			ids, err := tclient.ListViewInboxes(ctx, ApiKeyPrincipal(apiKey))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{inboxId})
+		})
+
+		t.Run("delete", func(t *testing.T) {
+			err := tclient.CanDeleteInbox(ctx, inboxId, MemberPrincipal(adminWarmerId))
+			assert.NoError(t, err)
+
+			err = tclient.CanDeleteInbox(ctx, inboxId, MemberPrincipal(ownerId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			ids, err := tclient.ListDeleteInboxes(ctx, MemberPrincipal(adminSequencesId))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{inboxId})
+		})
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		inboxes, err := tclient.ListInboxes(ctx, MemberPrincipal(ownerId))
+		assert.NoError(t, err)
+		assert.Nil(t, inboxes)
+
+		inboxes, err = tclient.ListInboxes(ctx, MemberPrincipal(adminWarmerId))
+		assert.NoError(t, err)
+		assert.Equal(t, inboxes, map[string]*Inbox{
+			inboxId: {Id: inboxId, View: true, Edit: true, Delete: true},
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := tclient.DeleteInboxOwner(ctx, inboxId, ownerId)
+		assert.NoError(t, err)
+
+		_, err = tclient.DeleteInboxOrganization(ctx, inboxId, orgId)
+		assert.NoError(t, err)
+
+		inboxes, err := tclient.ListInboxes(ctx, MemberPrincipal(adminWarmerId))
+		assert.NoError(t, err)
+		assert.Nil(t, inboxes)
+	})
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"rift/assert"
+)
+
+func TestMeeting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	adminId := "alice"
+	adminNoProductsId := "dave"
+	ownerId := "bob"
+	sdrId := "carol"
+	ownerNoProductsId := "erin"
+	meetingId := "meeting"
+	otherMeetingId := "other_meeting"
+
+	t.Run("relations", func(t *testing.T) {
+		t.Run("organization", func(t *testing.T) {
+			_, err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId, ProductMeetings)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationAdmin(ctx, orgId, adminNoProductsId)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationSDR(ctx, orgId, ownerId, ProductMeetings)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationSDR(ctx, orgId, sdrId, ProductMeetings)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationSDR(ctx, orgId, ownerNoProductsId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_organization", func(t *testing.T) {
+			_, err := tclient.WriteMeetingOrganization(ctx, meetingId, orgId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_owner", func(t *testing.T) {
+			_, err := tclient.WriteMeetingOwner(ctx, meetingId, ownerId)
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("permissions", func(t *testing.T) {
+		t.Run("edit", func(t *testing.T) {
+			err := tclient.CanEditMeeting(ctx, meetingId, MemberPrincipal(ownerId))
+			assert.NoError(t, err)
+
+			// edit is limited to the owner
+			err = tclient.CanEditMeeting(ctx, meetingId, MemberPrincipal(adminId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			ids, err := tclient.ListEditMeetings(ctx, MemberPrincipal(ownerId))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{meetingId})
+		})
+
+		t.Run("view", func(t *testing.T) {
+			err := tclient.CanViewMeeting(ctx, meetingId, MemberPrincipal(adminId))
+			assert.NoError(t, err)
+
+			err = tclient.CanViewMeeting(ctx, meetingId, MemberPrincipal(adminNoProductsId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			err = tclient.CanViewMeeting(ctx, meetingId, MemberPrincipal(sdrId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			ids, err := tclient.ListViewMeetings(ctx, MemberPrincipal(adminId))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{meetingId})
+		})
+
+		t.Run("delete", func(t *testing.T) {
+			err := tclient.CanDeleteMeeting(ctx, meetingId, MemberPrincipal(ownerId))
+			assert.NoError(t, err)
+
+			err = tclient.CanDeleteMeeting(ctx, meetingId, MemberPrincipal(adminId))
+			assert.NoError(t, err)
+
+			err = tclient.CanDeleteMeeting(ctx, meetingId, MemberPrincipal(adminNoProductsId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			ids, err := tclient.ListDeleteMeetings(ctx, MemberPrincipal(sdrId))
+			assert.NoError(t, err)
+			assert.Len(t, ids, 0)
+		})
+	})
+
+	t.Run("owner_no_products", func(t *testing.T) {
+		_, err := tclient.Tx().Touch(
+			RelationMeetingOrganization(otherMeetingId, orgId),
+			RelationMeetingOwner(otherMeetingId, ownerNoProductsId),
+		).Commit(ctx)
+		assert.NoError(t, err)
+
+		// owners need the meetings product too
+		owner := MemberPrincipal(ownerNoProductsId)
+		err = tclient.CanEditMeeting(ctx, otherMeetingId, owner)
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		err = tclient.CanDeleteMeeting(ctx, otherMeetingId, owner)
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		err = tclient.CanViewMeeting(ctx, otherMeetingId, owner)
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		meetings, err := tclient.ListMeetings(ctx, owner)
+		assert.NoError(t, err)
+		assert.Nil(t, meetings)
+
+		_, err = tclient.Tx().Delete(
+			RelationMeetingOrganization(otherMeetingId, orgId),
+			RelationMeetingOwner(otherMeetingId, ownerNoProductsId),
+		).Commit(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		meetings, err := tclient.ListMeetings(ctx, MemberPrincipal(adminNoProductsId))
+		assert.NoError(t, err)
+		assert.Nil(t, meetings)
+
+		meetings, err = tclient.ListMeetings(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Equal(t, meetings, map[string]*Meeting{
+			meetingId: {Id: meetingId, View: true, Edit: false, Delete: true},
+		})
+
+		meetings, err = tclient.ListMeetings(ctx, MemberPrincipal(ownerId))
+		assert.NoError(t, err)
+		assert.Equal(t, meetings, map[string]*Meeting{
+			meetingId: {Id: meetingId, View: true, Edit: true, Delete: true},
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := tclient.DeleteMeetingOwner(ctx, meetingId, ownerId)
+		assert.NoError(t, err)
+
+		meetings, err := tclient.ListMeetings(ctx, MemberPrincipal(ownerId))
+		assert.NoError(t, err)
+		assert.Nil(t, meetings)
+
+		_, err = tclient.DeleteMeetingOrganization(ctx, meetingId, orgId)
+		assert.NoError(t, err)
+
+		meetings, err = tclient.ListMeetings(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Nil(t, meetings)
+	})
+}
@@ -21,70 +21,70 @@ func TestOffDay(t *testing.T) {
 
 	t.Run("relations", func(t *testing.T) {
 		t.Run("relation_sdr", func(t *testing.T) {
-			err := tclient.WriteOrganizationSDR(ctx, orgId, sdrId)
+			_, err := tclient.WriteOrganizationSDR(ctx, orgId, sdrId)
 			assert.NoError(t, err)
 		})
 
 		t.Run("relation_admin", func(t *testing.T) {
-			err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
+			_, err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
 			assert.NoError(t, err)
 		})
 		t.Run("relation_organization", func(t *testing.T) {
-			err := tclient.WriteOffDayOrganization(ctx, offDayId, orgId)
+			_, err := tclient.WriteOffDayOrganization(ctx, offDayId, orgId)
 			assert.NoError(t, err)
 		})
 	})
 
 	t.Run("permissions", func(t *testing.T) {
 		t.Run("edit", func(t *testing.T) {
-			err := tclient.CanEditOffDay(ctx, offDayId, sdrId)
+			err := tclient.CanEditOffDay(ctx, offDayId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			ids, err := tclient.ListEditOffDays(ctx, sdrId)
+			ids, err := tclient.ListEditOffDays(ctx, MemberPrincipal(sdrId))
 			assert.NoError(t, err)
 			assert.Len(t, ids, 0)
 
-			err = tclient.CanEditOffDay(ctx, offDayId, adminId)
+			err = tclient.CanEditOffDay(ctx, offDayId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 
-			ids, err = tclient.ListEditOffDays(ctx, adminId)
+			ids, err = tclient.ListEditOffDays(ctx, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 			assert.Equal(t, ids, []string{offDayId})
 		})
 
 		t.Run("view", func(t *testing.T) {
-			err := tclient.CanViewOffDay(ctx, offDayId, sdrId)
+			err := tclient.CanViewOffDay(ctx, offDayId, MemberPrincipal(sdrId))
 			assert.NoError(t, err)
 
-			ids, err := tclient.ListViewOffDays(ctx, sdrId)
+			ids, err := tclient.ListViewOffDays(ctx, MemberPrincipal(sdrId))
 			assert.NoError(t, err)
 			assert.Equal(t, ids, []string{offDayId})
 
-			err = tclient.CanViewOffDay(ctx, offDayId, adminId)
+			err = tclient.CanViewOffDay(ctx, offDayId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 
-			ids, err = tclient.ListViewOffDays(ctx, adminId)
+			ids, err = tclient.ListViewOffDays(ctx, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 			assert.Equal(t, ids, []string{offDayId})
 		})
 
 		t.Run("delete", func(t *testing.T) {
-			err = tclient.CanDeleteOffDay(ctx, offDayId, sdrId)
+			err = tclient.CanDeleteOffDay(ctx, offDayId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanDeleteOffDay(ctx, offDayId, adminId)
+			err = tclient.CanDeleteOffDay(ctx, offDayId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 	})
 
 	t.Run("lookup", func(t *testing.T) {
-		offDays, err := tclient.ListOffDays(ctx, sdrId)
+		offDays, err := tclient.ListOffDays(ctx, MemberPrincipal(sdrId))
 		assert.NoError(t, err)
 		assert.Equal(t, offDays, map[string]*OffDay{
 			offDayId: {Id: offDayId, View: true, Edit: false, Delete: false},
 		})
 
-		offDays, err = tclient.ListOffDays(ctx, adminId)
+		offDays, err = tclient.ListOffDays(ctx, MemberPrincipal(adminId))
 		assert.NoError(t, err)
 		assert.Equal(t, offDays, map[string]*OffDay{
 			offDayId: {Id: offDayId, View: true, Edit: true, Delete: true},
@@ -92,10 +92,10 @@ func TestOffDay(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		err := tclient.DeleteOffDayOrganization(ctx, offDayId, orgId)
+		_, err := tclient.DeleteOffDayOrganization(ctx, offDayId, orgId)
 		assert.NoError(t, err)
 
-		offDays, err := tclient.ListOffDays(ctx, adminId)
+		offDays, err := tclient.ListOffDays(ctx, MemberPrincipal(adminId))
 		assert.NoError(t, err)
 		assert.Nil(t, offDays)
 	})
@@ -2,9 +2,17 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"testing"
 
 	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestOrganization(t *testing.T) {
@@ -21,176 +29,421 @@ func TestOrganization(t *testing.T) {
 
 	t.Run("relations", func(t *testing.T) {
 		t.Run("apikey", func(t *testing.T) {
-			err := tclient.WriteOrganizationApiKey(ctx, orgId, apiKey)
+			_, err := tclient.WriteOrganizationApiKey(ctx, orgId, apiKey)
 			assert.NoError(t, err)
 		})
 
 		t.Run("sdr", func(t *testing.T) {
-			err := tclient.WriteOrganizationSDR(ctx, orgId, sdrId)
+			_, err := tclient.WriteOrganizationSDR(ctx, orgId, sdrId)
 			assert.NoError(t, err)
 		})
 
 		t.Run("admin", func(t *testing.T) {
-			err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
+			_, err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
 			assert.NoError(t, err)
 		})
 	})
 
 	t.Run("permissions", func(t *testing.T) {
 		t.Run("edit_settings", func(t *testing.T) {
-			err := tclient.CanEditOrganizationSettings(ctx, orgId, sdrId)
+			err := tclient.CanEditOrganizationSettings(ctx, orgId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanEditOrganizationSettings(ctx, orgId, adminId)
+			err = tclient.CanEditOrganizationSettings(ctx, orgId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("view_settings", func(t *testing.T) {
-			err := tclient.CanViewOrganizationSettings(ctx, orgId, sdrId)
+			err := tclient.CanViewOrganizationSettings(ctx, orgId, MemberPrincipal(sdrId))
 			assert.NoError(t, err)
 
-			err = tclient.CanViewOrganizationSettings(ctx, orgId, adminId)
+			err = tclient.CanViewOrganizationSettings(ctx, orgId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("invite_member", func(t *testing.T) {
-			err := tclient.CanInviteOrganizationMember(ctx, orgId, sdrId)
+			err := tclient.CanInviteOrganizationMember(ctx, orgId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanInviteOrganizationMember(ctx, orgId, adminId)
+			err = tclient.CanInviteOrganizationMember(ctx, orgId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("edit_member", func(t *testing.T) {
-			err := tclient.CanEditOrganizationMember(ctx, orgId, sdrId)
+			err := tclient.CanEditOrganizationMember(ctx, orgId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanEditOrganizationMember(ctx, orgId, adminId)
+			err = tclient.CanEditOrganizationMember(ctx, orgId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("delete_member", func(t *testing.T) {
-			err := tclient.CanDeleteOrganizationMember(ctx, orgId, sdrId)
+			err := tclient.CanDeleteOrganizationMember(ctx, orgId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanDeleteOrganizationMember(ctx, orgId, adminId)
+			err = tclient.CanDeleteOrganizationMember(ctx, orgId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("create_team", func(t *testing.T) {
-			err := tclient.CanCreateOrganizationTeam(ctx, orgId, sdrId)
+			err := tclient.CanCreateOrganizationTeam(ctx, orgId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanCreateOrganizationTeam(ctx, orgId, adminId)
+			err = tclient.CanCreateOrganizationTeam(ctx, orgId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("create_password", func(t *testing.T) {
-			err := tclient.CanCreateOrganizationPassword(ctx, orgId, sdrId)
+			err := tclient.CanCreateOrganizationPassword(ctx, orgId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanCreateOrganizationPassword(ctx, orgId, adminId)
+			err = tclient.CanCreateOrganizationPassword(ctx, orgId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("create_offday", func(t *testing.T) {
-			err := tclient.CanCreateOrganizationOffDay(ctx, orgId, sdrId)
+			err := tclient.CanCreateOrganizationOffDay(ctx, orgId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanCreateOrganizationOffDay(ctx, orgId, adminId)
+			err = tclient.CanCreateOrganizationOffDay(ctx, orgId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("create_holiday", func(t *testing.T) {
-			err := tclient.CanCreateOrganizationHoliday(ctx, orgId, sdrId)
+			err := tclient.CanCreateOrganizationHoliday(ctx, orgId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanCreateOrganizationHoliday(ctx, orgId, adminId)
+			err = tclient.CanCreateOrganizationHoliday(ctx, orgId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("create_sequence", func(t *testing.T) {
-			err := tclient.CanCreateOrganizationSequence(ctx, orgId, sdrId)
-			assert.NoError(t, err)
+			err := tclient.CanCreateOrganizationSequence(ctx, orgId, MemberPrincipal(sdrId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			err = tclient.CanCreateOrganizationSequence(ctx, orgId, MemberPrincipal(adminId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+		})
 
-			err = tclient.CanCreateOrganizationSequence(ctx, orgId, adminId)
+		t.Run("create_inbox", func(t *testing.T) {
+			err := tclient.CanCreateOrganizationInbox(ctx, orgId, MemberPrincipal(sdrId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			err = tclient.CanCreateOrganizationInbox(ctx, orgId, MemberPrincipal(adminId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+		})
+
+		t.Run("create_meeting", func(t *testing.T) {
+			err := tclient.CanCreateOrganizationMeeting(ctx, orgId, MemberPrincipal(sdrId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			err = tclient.CanCreateOrganizationMeeting(ctx, orgId, MemberPrincipal(adminId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+		})
+	})
+
+	t.Run("products", func(t *testing.T) {
+		sequencesId := "carol"
+		warmerId := "dave"
+
+		_, err := tclient.WriteOrganizationSDR(ctx, orgId, sequencesId, ProductSequences)
+		assert.NoError(t, err)
+
+		_, err = tclient.WriteOrganizationAdmin(ctx, orgId, warmerId, ProductWarmer)
+		assert.NoError(t, err)
+
+		t.Run("create_sequence", func(t *testing.T) {
+			err := tclient.CanCreateOrganizationSequence(ctx, orgId, MemberPrincipal(sequencesId))
 			assert.NoError(t, err)
+
+			err = tclient.CanCreateOrganizationSequence(ctx, orgId, MemberPrincipal(warmerId))
+			assert.ErrorContains(t, err, &ErrDenied{})
 		})
 
 		t.Run("create_inbox", func(t *testing.T) {
-			err := tclient.CanCreateOrganizationInbox(ctx, orgId, sdrId)
+			// sdr is not allowed to create inbox regardless of products
+			err := tclient.CanCreateOrganizationInbox(ctx, orgId, MemberPrincipal(sequencesId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanCreateOrganizationInbox(ctx, orgId, adminId)
+			// sequences or warmer is enough
+			err = tclient.CanCreateOrganizationInbox(ctx, orgId, MemberPrincipal(warmerId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("create_meeting", func(t *testing.T) {
-			err := tclient.CanCreateOrganizationMeeting(ctx, orgId, sdrId)
-			assert.NoError(t, err)
+			err := tclient.CanCreateOrganizationMeeting(ctx, orgId, MemberPrincipal(sequencesId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			err = tclient.CanCreateOrganizationMeeting(ctx, orgId, MemberPrincipal(warmerId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+		})
 
-			err = tclient.CanCreateOrganizationMeeting(ctx, orgId, adminId)
+		t.Run("lookup", func(t *testing.T) {
+			org, err := tclient.GetOrganization(ctx, orgId, MemberPrincipal(sequencesId))
 			assert.NoError(t, err)
+			assert.Equal(t, org.CreateSequence, true)
+			assert.Equal(t, org.CreateMeeting, false)
 		})
+
+		_, err = tclient.DeleteOrganizationSDR(ctx, orgId, sequencesId)
+		assert.NoError(t, err)
+
+		_, err = tclient.DeleteOrganizationAdmin(ctx, orgId, warmerId)
+		assert.NoError(t, err)
 	})
 
 	t.Run("lookup", func(t *testing.T) {
-		org, err := tclient.GetOrganization(ctx, sdrId)
+		org, err := tclient.GetOrganization(ctx, orgId, MemberPrincipal(sdrId))
 		assert.NoError(t, err)
 		assert.Equal(t, org, &Organization{
 			Id:             orgId,
 			Access:         true,
 			CreateHoliday:  false,
 			CreateInbox:    false,
-			CreateMeeting:  true,
+			CreateMeeting:  false,
 			CreateOffDay:   false,
 			CreatePassword: false,
-			CreateSequence: true,
+			CreateSequence: false,
 			CreateTeam:     false,
 			DeleteMember:   false,
 			EditMember:     false,
 			EditSettings:   false,
 			InviteMember:   false,
+			ManageSeat:     false,
 			ViewSettings:   true,
 		})
 
-		org, err = tclient.GetOrganization(ctx, adminId)
+		org, err = tclient.GetOrganization(ctx, orgId, MemberPrincipal(adminId))
 		assert.NoError(t, err)
 		assert.Equal(t, org, &Organization{
 			Id:             orgId,
 			Access:         true,
 			CreateHoliday:  true,
-			CreateInbox:    true,
-			CreateMeeting:  true,
+			CreateInbox:    false,
+			CreateMeeting:  false,
 			CreateOffDay:   true,
 			CreatePassword: true,
-			CreateSequence: true,
+			CreateSequence: false,
 			CreateTeam:     true,
 			DeleteMember:   true,
 			EditMember:     true,
 			EditSettings:   true,
 			InviteMember:   true,
+			ManageSeat:     true,
 			ViewSettings:   true,
 		})
 	})
 
+	t.Run("many_organizations", func(t *testing.T) {
+		agencyOrgId := "agency"
+
+		_, err := tclient.WriteOrganizationSDR(ctx, agencyOrgId, adminId)
+		assert.NoError(t, err)
+
+		orgs, err := tclient.ListOrganizations(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Equal(t, len(orgs), 2)
+		assert.Equal(t, orgs[orgId].EditSettings, true)
+		assert.Equal(t, orgs[agencyOrgId].EditSettings, false)
+		assert.Equal(t, orgs[agencyOrgId].ViewSettings, true)
+
+		org, err := tclient.GetOrganization(ctx, agencyOrgId, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Equal(t, org, orgs[agencyOrgId])
+
+		_, err = tclient.DeleteOrganizationSDR(ctx, agencyOrgId, adminId)
+		assert.NoError(t, err)
+	})
+
+	t.Run("lookup_subjects", func(t *testing.T) {
+		var admins, sdrs, apiKeys, editors []string
+
+		err := tclient.LookupOrganizationAdmins(ctx, orgId, collectSubjects(&admins))
+		assert.NoError(t, err)
+		assert.Equal(t, admins, []string{adminId})
+
+		err = tclient.LookupOrganizationSDRs(ctx, orgId, collectSubjects(&sdrs))
+		assert.NoError(t, err)
+		assert.Equal(t, sdrs, []string{sdrId})
+
+		err = tclient.LookupOrganizationApiKeys(ctx, orgId, collectSubjects(&apiKeys))
+		assert.NoError(t, err)
+		assert.Equal(t, apiKeys, []string{apiKey})
+
+		err = tclient.LookupEditOrganizationSettingsMembers(ctx, orgId, collectSubjects(&editors))
+		assert.NoError(t, err)
+		assert.Equal(t, editors, []string{adminId})
+	})
+
 	t.Run("delete", func(t *testing.T) {
 		t.Run("apikey", func(t *testing.T) {
-			err := tclient.DeleteOrganizationApiKey(ctx, orgId, apiKey)
+			_, err := tclient.DeleteOrganizationApiKey(ctx, orgId, apiKey)
 			assert.NoError(t, err)
 		})
 
 		t.Run("sdr", func(t *testing.T) {
-			err := tclient.DeleteOrganizationSDR(ctx, orgId, sdrId)
+			_, err := tclient.DeleteOrganizationSDR(ctx, orgId, sdrId)
 			assert.NoError(t, err)
 		})
 
 		t.Run("admin", func(t *testing.T) {
-			err := tclient.DeleteOrganizationAdmin(ctx, orgId, adminId)
+			_, err := tclient.DeleteOrganizationAdmin(ctx, orgId, adminId)
 			assert.NoError(t, err)
 
-			_, err = tclient.GetOrganization(ctx, adminId)
+			_, err = tclient.GetOrganization(ctx, orgId, MemberPrincipal(adminId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 		})
 	})
 }
+
+func TestDeleteOrganization(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const (
+		writeMethod = "/authzed.api.v1.PermissionsService/WriteRelationships"
+		readMethod  = "/authzed.api.v1.PermissionsService/ReadRelationships"
+	)
+
+	// failWrite fails the nth write, 0 disables failures
+	writes, failWrite := 0, 0
+	reads := 0
+	tclient, err := StartTestServer(ctx, WithStreamInterceptors(
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			if method == readMethod {
+				reads++
+			}
+			return streamer(ctx, desc, cc, method, opts...)
+		},
+	), WithUnaryInterceptors(
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if method == writeMethod {
+				writes++
+				if writes == failWrite {
+					return status.Error(codes.InvalidArgument, "interrupted")
+				}
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		},
+	))
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	otherOrgId := "other"
+	offDays := 2500
+
+	setup := func(t *testing.T) {
+		updates := []*pb.RelationshipUpdate{}
+		touch := func(rels ...*pb.Relationship) {
+			for _, rel := range rels {
+				updates = append(updates, &pb.RelationshipUpdate{
+					Operation:    pb.RelationshipUpdate_OPERATION_TOUCH,
+					Relationship: rel,
+				})
+			}
+		}
+
+		touch(
+			RelationOrganizationApiKey(orgId, "key"),
+			RelationOrganizationAdmin(orgId, "alice"),
+			RelationOrganizationSDR(orgId, "bob"),
+			RelationTeamOrganization("team", orgId),
+			RelationContactOrganization("contact", orgId),
+			RelationContactOwner("contact", "bob"),
+			RelationSequenceOrganization("sequence", orgId),
+			RelationSequenceOwner("sequence", "bob"),
+			RelationSequenceSenderTeam("sequence", "team"),
+			RelationSequenceContact("sequence", "contact"),
+			RelationSequenceViewer("sequence", "alice"),
+			RelationSequenceActionSequence("action1", "sequence"),
+			RelationSequenceActionAssignee("action1", "bob"),
+			RelationSequenceActionSequence("action2", "sequence"),
+			RelationSequenceActionAssignee("action2", "alice"),
+			RelationMeetingOrganization("meeting", orgId),
+			RelationMeetingOwner("meeting", "alice"),
+		)
+		for i := 0; i < offDays; i++ {
+			touch(RelationOffDayOrganization(fmt.Sprintf("offday%04d", i), orgId))
+		}
+
+		// resources of other organization must stay intact
+		touch(
+			RelationOrganizationAdmin(otherOrgId, "alice"),
+			RelationOffDayOrganization("other-offday", otherOrgId),
+			RelationSequenceOrganization("other-sequence", otherOrgId),
+			RelationSequenceActionSequence("other-action", "other-sequence"),
+		)
+
+		_, err := tclient.WriteRelationshipsBulk(ctx, updates)
+		assert.NoError(t, err)
+	}
+
+	remaining := func(t *testing.T) []string {
+		var all []string
+		for _, definition := range []string{
+			definitionOrganization, definitionTeam, definitionOffDay, definitionContact,
+			definitionSequence, definitionSequenceAction, definitionMeeting,
+		} {
+			rels, err := tclient.ReadRelationships(ctx, &pb.RelationshipFilter{ResourceType: definition})
+			assert.NoError(t, err)
+			for _, rel := range rels {
+				all = append(all, tuple.MustStringRelationship(rel))
+			}
+		}
+		sort.Strings(all)
+		return all
+	}
+
+	wantRemaining := []string{
+		"offday:other-offday#organization@organization:other",
+		`organization:other#admin@member:alice[products:{"enabled":[]}]`,
+		"sequence/action:other-action#sequence@sequence:other-sequence",
+		"sequence:other-sequence#organization@organization:other",
+	}
+
+	wantDeleted := map[string]int{
+		definitionOrganization:   3,
+		definitionTeam:           1,
+		definitionOffDay:         offDays,
+		definitionContact:        2,
+		definitionSequence:       5,
+		definitionSequenceAction: 4,
+		definitionMeeting:        2,
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		setup(t)
+
+		reads = 0
+		report, err := tclient.DeleteOrganization(ctx, orgId)
+		assert.NoError(t, err)
+		assert.Equal(t, report.Deleted, wantDeleted)
+		// resources are read in pages, not one by one
+		assert.True(t, reads < 50)
+		assert.Equal(t, remaining(t), wantRemaining)
+
+		err = tclient.CanViewSequence(ctx, "sequence", MemberPrincipal("bob"), FullyConsistent())
+		assert.ErrorContains(t, err, &ErrDenied{})
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		report, err := tclient.DeleteOrganization(ctx, orgId)
+		assert.NoError(t, err)
+		assert.Equal(t, report.Total(), 0)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		setup(t)
+
+		writes, failWrite = 0, 5
+		interrupted, err := tclient.DeleteOrganization(ctx, orgId)
+		assert.ErrorContains(t, err, "interrupted")
+		assert.True(t, interrupted.Total() > 0)
+
+		failWrite = 0
+		resumed, err := tclient.DeleteOrganization(ctx, orgId)
+		assert.NoError(t, err)
+		assert.Equal(t, interrupted.Total()+resumed.Total(), (&DeleteReport{Deleted: wantDeleted}).Total())
+		assert.Equal(t, remaining(t), wantRemaining)
+	})
+}
@@ -22,58 +22,87 @@ func TestPassword(t *testing.T) {
 
 	t.Run("organization", func(t *testing.T) {
 		t.Run("relation_apikey", func(t *testing.T) {
-			err := tclient.WriteOrganizationApiKey(ctx, orgId, apiKey)
+			_, err := tclient.WriteOrganizationApiKey(ctx, orgId, apiKey)
 			assert.NoError(t, err)
 		})
 
 		t.Run("relation_sdr", func(t *testing.T) {
-			err := tclient.WriteOrganizationSDR(ctx, orgId, sdrId)
+			_, err := tclient.WriteOrganizationSDR(ctx, orgId, sdrId)
 			assert.NoError(t, err)
 		})
 
 		t.Run("relation_admin", func(t *testing.T) {
-			err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
+			_, err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
 			assert.NoError(t, err)
 		})
 	})
 
 	t.Run("password", func(t *testing.T) {
 		t.Run("relation_organization", func(t *testing.T) {
-			err := tclient.WritePasswordOrganization(ctx, passwordId, orgId)
+			_, err := tclient.WritePasswordOrganization(ctx, passwordId, orgId)
 			assert.NoError(t, err)
 		})
 
 		t.Run("edit", func(t *testing.T) {
-			err := tclient.CanEditPassword(ctx, passwordId, apiKey)
+			err := tclient.CanEditPassword(ctx, passwordId, MemberPrincipal(apiKey))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanEditPassword(ctx, passwordId, sdrId)
+			err = tclient.CanEditPassword(ctx, passwordId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanEditPassword(ctx, passwordId, adminId)
+			err = tclient.CanEditPassword(ctx, passwordId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("view", func(t *testing.T) {
-			err := tclient.CanViewPassword(ctx, passwordId, apiKey)
+			err := tclient.CanViewPassword(ctx, passwordId, MemberPrincipal(apiKey))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanViewPassword(ctx, passwordId, sdrId)
+			err = tclient.CanViewPassword(ctx, passwordId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanViewPassword(ctx, passwordId, adminId)
+			err = tclient.CanViewPassword(ctx, passwordId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("delete", func(t *testing.T) {
-			err := tclient.CanDeletePassword(ctx, passwordId, apiKey)
+			err := tclient.CanDeletePassword(ctx, passwordId, MemberPrincipal(apiKey))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanDeletePassword(ctx, passwordId, sdrId)
+			err = tclient.CanDeletePassword(ctx, passwordId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanDeletePassword(ctx, passwordId, adminId)
+			err = tclient.CanDeletePassword(ctx, passwordId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 	})
+
+	t.Run("lookup", func(t *testing.T) {
+		ids, err := tclient.ListViewPasswords(ctx, MemberPrincipal(sdrId))
+		assert.NoError(t, err)
+		assert.Len(t, ids, 0)
+
+		items, err := tclient.ListPasswords(ctx, MemberPrincipal(sdrId))
+		assert.NoError(t, err)
+		assert.Nil(t, items)
+
+		ids, err = tclient.ListDeletePasswords(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{passwordId})
+
+		items, err = tclient.ListPasswords(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Equal(t, items, map[string]*Password{
+			passwordId: {Id: passwordId, View: true, Edit: true, Delete: true},
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := tclient.DeletePasswordOrganization(ctx, passwordId, orgId)
+		assert.NoError(t, err)
+
+		items, err := tclient.ListPasswords(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Nil(t, items)
+	})
 }
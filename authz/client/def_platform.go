@@ -8,31 +8,22 @@ import (
 
 const platformId = "rift"
 
+var platformResource = newResource[struct{}](definitionPlatform, []string{permissionChameleon}, nil)
+
 func (c *Client) WritePlatfromChameleoner(
 	ctx context.Context,
 	userId string,
 	email string,
-) error {
-	rel := &pb.Relationship{
-		Resource: objRef(definitionPlatform, platformId),
-		Relation: relationChameleoner,
-		Subject:  subRef(definitionUser, userId),
-		OptionalCaveat: &pb.ContextualizedCaveat{
-			CaveatName: caveatChameleonEmail,
-			Context:    newCaveatChameleonEmail(email),
-		},
+) (*pb.ZedToken, error) {
+	rel := platformResource.Relation(platformId, relationChameleoner, subRef(definitionUser, userId))
+	rel.OptionalCaveat = &pb.ContextualizedCaveat{
+		CaveatName: caveatChameleonEmail,
+		Context:    newCaveatChameleonEmail(email),
 	}
 
 	return c.writeRelationship(ctx, rel)
 }
 
-func (c *Client) CanChameleon(ctx context.Context, userId string) error {
-	req := &pb.CheckPermissionRequest{
-		Resource:    objRef(definitionPlatform, platformId),
-		Permission:  permissionChameleon,
-		Subject:     subRef(definitionUser, userId),
-		Consistency: fullConsistency(),
-	}
-
-	return c.checkPermission(ctx, req)
+func (c *Client) CanChameleon(ctx context.Context, principal Principal, opts ...ReadOption) error {
+	return platformResource.Can(ctx, c, platformId, permissionChameleon, principal.ref(), opts...)
 }
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
 )
 
 func TestPlatform(t *testing.T) {
@@ -23,25 +25,80 @@ func TestPlatform(t *testing.T) {
 	nonRiftUserId := "charlie"
 	nonRiftEmail := "charlie@example.com"
 
+	unknownEmailUserId := "dave"
+
 	t.Run("relation_chameleoner", func(t *testing.T) {
-		err := tclient.WritePlatfromChameleoner(ctx, riftUserId, riftEmail)
+		_, err := tclient.WritePlatfromChameleoner(ctx, riftUserId, riftEmail)
 		assert.NoError(t, err)
 
-		err = tclient.WritePlatfromChameleoner(ctx, getRiftUserId, getRiftEmail)
+		_, err = tclient.WritePlatfromChameleoner(ctx, getRiftUserId, getRiftEmail)
 		assert.NoError(t, err)
 
-		err = tclient.WritePlatfromChameleoner(ctx, nonRiftUserId, nonRiftEmail)
+		_, err = tclient.WritePlatfromChameleoner(ctx, nonRiftUserId, nonRiftEmail)
 		assert.NoError(t, err)
 	})
 
 	t.Run("chameleon", func(t *testing.T) {
-		err := tclient.CanChameleon(ctx, riftUserId)
+		err := tclient.CanChameleon(ctx, UserPrincipal(riftUserId))
 		assert.NoError(t, err)
 
-		err = tclient.CanChameleon(ctx, getRiftUserId)
+		err = tclient.CanChameleon(ctx, UserPrincipal(getRiftUserId))
 		assert.NoError(t, err)
 
-		err = tclient.CanChameleon(ctx, nonRiftUserId)
+		err = tclient.CanChameleon(ctx, UserPrincipal(nonRiftUserId))
 		assert.ErrorContains(t, err, &ErrDenied{})
 	})
+
+	t.Run("caveat_context", func(t *testing.T) {
+		// the email is not stored with the relationship,
+		// it's passed with lookups and checks
+		rel := platformResource.Relation(platformId, relationChameleoner, subRef(definitionUser, unknownEmailUserId))
+		rel.OptionalCaveat = &pb.ContextualizedCaveat{CaveatName: caveatChameleonEmail}
+		_, err := tclient.writeRelationship(ctx, rel)
+		assert.NoError(t, err)
+
+		subject := UserPrincipal(unknownEmailUserId).ref()
+		riftEmail := WithCaveatContext(map[string]any{caveatChameleonEmailArg: "dave@rift.com"})
+		otherEmail := WithCaveatContext(map[string]any{caveatChameleonEmailArg: "dave@example.com"})
+
+		ids, err := platformResource.List(ctx, tclient, permissionChameleon, subject, riftEmail)
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{platformId})
+
+		ids, err = platformResource.List(ctx, tclient, permissionChameleon, subject, otherEmail)
+		assert.NoError(t, err)
+		assert.Len(t, ids, 0)
+
+		it := platformResource.Iterate(ctx, tclient, permissionChameleon, subject, riftEmail)
+		ids = nil
+		for it.Next() {
+			ids = append(ids, it.Value())
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, ids, []string{platformId})
+
+		// platform has no capabilities, so they are built here
+		type platform struct{ Chameleon bool }
+		platformCapabilities := newResource(definitionPlatform, []string{permissionChameleon},
+			func(id string, granted map[string]bool) *platform {
+				return &platform{Chameleon: granted[permissionChameleon]}
+			},
+		)
+
+		got, err := platformCapabilities.Get(ctx, tclient, platformId, subject, riftEmail)
+		assert.NoError(t, err)
+		assert.True(t, got.Chameleon)
+
+		_, err = platformCapabilities.Get(ctx, tclient, platformId, subject, otherEmail)
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		all, err := platformCapabilities.ListWithCapabilities(ctx, tclient, subject, riftEmail)
+		assert.NoError(t, err)
+		assert.Equal(t, all, map[string]*platform{platformId: {Chameleon: true}})
+
+		// invalid context fails before the lookup
+		it = platformResource.Iterate(ctx, tclient, permissionChameleon, subject, WithCaveatContext(map[string]any{"x": struct{}{}}))
+		assert.True(t, !it.Next())
+		assert.ErrorContains(t, it.Err(), "caveat context")
+	})
 }
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+// ReassignSequenceAction moves the action from one assignee to another
+// in a single transaction. It fails with ErrPreconditionFailed if the action
+// is not assigned to fromMemberId anymore, so a concurrent reassignment
+// is not overwritten. Nothing is written if both members are the same,
+// then the returned token is nil, but the assignment must still exist.
+func (c *Client) ReassignSequenceAction(
+	ctx context.Context,
+	actionId string,
+	fromMemberId string,
+	toMemberId string,
+) (*pb.ZedToken, error) {
+	from := RelationSequenceActionAssignee(actionId, fromMemberId)
+	if fromMemberId == toMemberId {
+		found, err := c.HasRelationships(ctx, ExactFilter(from), FullyConsistent())
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("authz: %q does not exist: %w", relstr(from), ErrPreconditionFailed)
+		}
+		return nil, nil
+	}
+
+	to := RelationSequenceActionAssignee(actionId, toMemberId)
+	return c.Tx().
+		Delete(from).
+		Touch(to).
+		MustExist(ExactFilter(from)).
+		Commit(ctx)
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+func TestSequenceAction(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	adminId := "alice"
+	adminNoProductsId := "dave"
+	sdrId := "bob"
+	otherSdrId := "carol"
+	sdrNoProductsId := "erin"
+	sequenceId := "sequence"
+	actionId := "action"
+	otherActionId := "other_action"
+
+	t.Run("relations", func(t *testing.T) {
+		t.Run("organization", func(t *testing.T) {
+			_, err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId, ProductSequences)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationAdmin(ctx, orgId, adminNoProductsId)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationSDR(ctx, orgId, sdrId, ProductSequences)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationSDR(ctx, orgId, otherSdrId, ProductSequences)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationSDR(ctx, orgId, sdrNoProductsId)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteSequenceOrganization(ctx, sequenceId, orgId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_sequence", func(t *testing.T) {
+			_, err := tclient.WriteSequenceActionSequence(ctx, actionId, sequenceId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_assignee", func(t *testing.T) {
+			_, err := tclient.AssignSequenceAction(ctx, actionId, sdrId)
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("permissions", func(t *testing.T) {
+		t.Run("edit", func(t *testing.T) {
+			err := tclient.CanEditSequenceAction(ctx, actionId, MemberPrincipal(sdrId))
+			assert.NoError(t, err)
+
+			err = tclient.CanEditSequenceAction(ctx, actionId, MemberPrincipal(adminId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			err = tclient.CanEditSequenceAction(ctx, actionId, MemberPrincipal(otherSdrId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+		})
+
+		t.Run("view", func(t *testing.T) {
+			err := tclient.CanViewSequenceAction(ctx, actionId, MemberPrincipal(sdrId))
+			assert.NoError(t, err)
+
+			err = tclient.CanViewSequenceAction(ctx, actionId, MemberPrincipal(adminId))
+			assert.NoError(t, err)
+
+			err = tclient.CanViewSequenceAction(ctx, actionId, MemberPrincipal(adminNoProductsId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			err = tclient.CanViewSequenceAction(ctx, actionId, MemberPrincipal(otherSdrId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+		})
+	})
+
+	t.Run("assignee_no_products", func(t *testing.T) {
+		rels := []*pb.Relationship{
+			RelationSequenceActionSequence(otherActionId, sequenceId),
+			RelationSequenceActionAssignee(otherActionId, sdrNoProductsId),
+		}
+		_, err := tclient.Tx().Touch(rels...).Commit(ctx)
+		assert.NoError(t, err)
+
+		// assignees need the sequences product too
+		assignee := MemberPrincipal(sdrNoProductsId)
+		err = tclient.CanEditSequenceAction(ctx, otherActionId, assignee)
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		err = tclient.CanViewSequenceAction(ctx, otherActionId, assignee)
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		ids, err := tclient.ListAssignedSequenceActions(ctx, assignee)
+		assert.NoError(t, err)
+		assert.Len(t, ids, 0)
+
+		_, err = tclient.Tx().Delete(rels...).Commit(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		ids, err := tclient.ListAssignedSequenceActions(ctx, MemberPrincipal(sdrId))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{actionId})
+
+		ids, err = tclient.ListAssignedSequenceActions(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Len(t, ids, 0)
+
+		actions, err := tclient.ListSequenceActions(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Equal(t, actions, map[string]*SequenceAction{
+			actionId: {Id: actionId, View: true},
+		})
+
+		actions, err = tclient.ListSequenceActions(ctx, MemberPrincipal(sdrId))
+		assert.NoError(t, err)
+		assert.Equal(t, actions, map[string]*SequenceAction{
+			actionId: {Id: actionId, View: true, Edit: true},
+		})
+	})
+
+	t.Run("reassign", func(t *testing.T) {
+		_, err := tclient.ReassignSequenceAction(ctx, actionId, sdrId, otherSdrId)
+		assert.NoError(t, err)
+
+		ids, err := tclient.ListAssignedSequenceActions(ctx, MemberPrincipal(sdrId))
+		assert.NoError(t, err)
+		assert.Len(t, ids, 0)
+
+		ids, err = tclient.ListAssignedSequenceActions(ctx, MemberPrincipal(otherSdrId))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{actionId})
+
+		// action is not assigned to sdr anymore
+		_, err = tclient.ReassignSequenceAction(ctx, actionId, sdrId, adminId)
+		assert.True(t, errors.Is(err, ErrPreconditionFailed))
+
+		ids, err = tclient.ListAssignedSequenceActions(ctx, MemberPrincipal(otherSdrId))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{actionId})
+
+		// reassignment to the same member is a no-op
+		token, err := tclient.ReassignSequenceAction(ctx, actionId, otherSdrId, otherSdrId)
+		assert.NoError(t, err)
+		assert.Nil(t, token)
+
+		ids, err = tclient.ListAssignedSequenceActions(ctx, MemberPrincipal(otherSdrId))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{actionId})
+
+		// the no-op still requires the assignment
+		token, err = tclient.ReassignSequenceAction(ctx, actionId, sdrId, sdrId)
+		assert.True(t, errors.Is(err, ErrPreconditionFailed))
+		assert.Nil(t, token)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := tclient.UnassignSequenceAction(ctx, actionId, otherSdrId)
+		assert.NoError(t, err)
+
+		err = tclient.CanEditSequenceAction(ctx, actionId, MemberPrincipal(otherSdrId))
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		_, err = tclient.DeleteSequenceActionSequence(ctx, actionId, sequenceId)
+		assert.NoError(t, err)
+
+		err = tclient.CanViewSequenceAction(ctx, actionId, MemberPrincipal(adminId))
+		assert.ErrorContains(t, err, &ErrDenied{})
+	})
+}
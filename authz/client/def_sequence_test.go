@@ -0,0 +1,331 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+func TestSequence(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	apiKey := "key"
+	adminId := "alice"
+	adminNoCallsId := "grace"
+	adminNoProductsId := "dave"
+	ownerId := "bob"
+	viewerId := "carol"
+	editorId := "erin"
+	senderId := "frank"
+	noProductsId := "heidi"
+	teamId := "team"
+	contactId := "contact"
+	sequenceId := "sequence"
+	otherSequenceId := "other_sequence"
+
+	t.Run("relations", func(t *testing.T) {
+		t.Run("organization", func(t *testing.T) {
+			_, err := tclient.WriteOrganizationApiKey(ctx, orgId, apiKey)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationAdmin(ctx, orgId, adminId, ProductSequences, ProductCalls)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationAdmin(ctx, orgId, adminNoCallsId, ProductSequences)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationAdmin(ctx, orgId, adminNoProductsId)
+			assert.NoError(t, err)
+
+			for _, id := range []string{ownerId, viewerId, senderId} {
+				_, err = tclient.WriteOrganizationSDR(ctx, orgId, id, ProductSequences)
+				assert.NoError(t, err)
+			}
+
+			_, err = tclient.WriteOrganizationSDR(ctx, orgId, editorId, ProductSequences, ProductCalls)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteOrganizationSDR(ctx, orgId, noProductsId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_organization", func(t *testing.T) {
+			_, err := tclient.WriteSequenceOrganization(ctx, sequenceId, orgId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_owner", func(t *testing.T) {
+			_, err := tclient.WriteSequenceOwner(ctx, sequenceId, ownerId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_viewer", func(t *testing.T) {
+			_, err := tclient.WriteSequenceViewer(ctx, sequenceId, viewerId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_editor", func(t *testing.T) {
+			_, err := tclient.WriteSequenceEditor(ctx, sequenceId, editorId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_sender", func(t *testing.T) {
+			_, err := tclient.WriteSequenceSender(ctx, sequenceId, senderId)
+			assert.NoError(t, err)
+
+			_, err = tclient.WriteSequenceSenderTeam(ctx, sequenceId, teamId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("relation_contact", func(t *testing.T) {
+			_, err := tclient.WriteSequenceContact(ctx, sequenceId, contactId)
+			assert.NoError(t, err)
+		})
+
+		t.Run("read", func(t *testing.T) {
+			rels, err := tclient.ReadRelationships(ctx, &pb.RelationshipFilter{
+				ResourceType:       definitionSequence,
+				OptionalResourceId: sequenceId,
+			})
+			assert.NoError(t, err)
+
+			relStrs := make([]string, len(rels))
+			for i, rel := range rels {
+				relStrs[i] = tuple.MustStringRelationship(rel)
+			}
+			sort.Strings(relStrs)
+			assert.Equal(t, relStrs, []string{
+				"sequence:sequence#contact@contact:contact",
+				"sequence:sequence#editor@member:erin",
+				"sequence:sequence#organization@organization:rift",
+				"sequence:sequence#owner@member:bob",
+				"sequence:sequence#sender@member:frank",
+				"sequence:sequence#sender@team:team",
+				"sequence:sequence#viewer@member:carol",
+			})
+		})
+	})
+
+	t.Run("permissions", func(t *testing.T) {
+		t.Run("edit", func(t *testing.T) {
+			for _, id := range []string{ownerId, editorId, adminId} {
+				err := tclient.CanEditSequence(ctx, sequenceId, MemberPrincipal(id))
+				assert.NoError(t, err)
+			}
+
+			for _, id := range []string{viewerId, senderId, adminNoProductsId} {
+				err := tclient.CanEditSequence(ctx, sequenceId, MemberPrincipal(id))
+				assert.ErrorContains(t, err, &ErrDenied{})
+			}
+
+			ids, err := tclient.ListEditSequences(ctx, MemberPrincipal(adminNoProductsId))
+			assert.NoError(t, err)
+			assert.Len(t, ids, 0)
+
+			ids, err = tclient.ListEditSequences(ctx, MemberPrincipal(editorId))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{sequenceId})
+		})
+
+		t.Run("view", func(t *testing.T) {
+			for _, id := range []string{ownerId, editorId, adminId, viewerId, senderId} {
+				err := tclient.CanViewSequence(ctx, sequenceId, MemberPrincipal(id))
+				assert.NoError(t, err)
+			}
+
+			err := tclient.CanViewSequence(ctx, sequenceId, MemberPrincipal(adminNoProductsId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			ids, err := tclient.ListViewSequences(ctx, MemberPrincipal(senderId))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{sequenceId})
+
+			err = tclient.CanViewSequence(ctx, sequenceId, ApiKeyPrincipal(apiKey))
+			assert.NoError(t, err)
+
+			ids, err = tclient.ListViewSequences(ctx, ApiKeyPrincipal(apiKey))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{sequenceId})
+
+			// teams have no products enabled
+			err = tclient.CanViewSequence(ctx, sequenceId, TeamPrincipal(teamId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			err = tclient.CanEditSequence(ctx, sequenceId, TeamPrincipal(teamId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+		})
+
+		t.Run("delete", func(t *testing.T) {
+			for _, id := range []string{ownerId, adminId} {
+				err := tclient.CanDeleteSequence(ctx, sequenceId, MemberPrincipal(id))
+				assert.NoError(t, err)
+			}
+
+			for _, id := range []string{editorId, viewerId, adminNoProductsId} {
+				err := tclient.CanDeleteSequence(ctx, sequenceId, MemberPrincipal(id))
+				assert.ErrorContains(t, err, &ErrDenied{})
+			}
+
+			ids, err := tclient.ListDeleteSequences(ctx, MemberPrincipal(ownerId))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{sequenceId})
+		})
+
+		t.Run("upload_contact", func(t *testing.T) {
+			err := tclient.CanUploadSequenceContact(ctx, sequenceId, MemberPrincipal(editorId))
+			assert.NoError(t, err)
+
+			err = tclient.CanUploadSequenceContact(ctx, sequenceId, MemberPrincipal(viewerId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			err = tclient.CanUploadSequenceContact(ctx, sequenceId, ApiKeyPrincipal(apiKey))
+			assert.NoError(t, err)
+
+			ids, err := tclient.ListUploadContactSequences(ctx, MemberPrincipal(editorId))
+			assert.NoError(t, err)
+			assert.Equal(t, ids, []string{sequenceId})
+		})
+
+		t.Run("create_call_step", func(t *testing.T) {
+			err := tclient.CanCreateSequenceCallStep(ctx, sequenceId, MemberPrincipal(adminId))
+			assert.NoError(t, err)
+
+			err = tclient.CanCreateSequenceCallStep(ctx, sequenceId, MemberPrincipal(editorId))
+			assert.NoError(t, err)
+
+			err = tclient.CanCreateSequenceCallStep(ctx, sequenceId, MemberPrincipal(adminNoCallsId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			// owner has sequences, but not calls
+			err = tclient.CanCreateSequenceCallStep(ctx, sequenceId, MemberPrincipal(ownerId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			ids, err := tclient.ListCreateCallStepSequences(ctx, MemberPrincipal(adminNoCallsId))
+			assert.NoError(t, err)
+			assert.Len(t, ids, 0)
+		})
+
+		t.Run("organization_admin", func(t *testing.T) {
+			err := tclient.CanSequenceOrganizationAdmin(ctx, sequenceId, MemberPrincipal(adminId))
+			assert.NoError(t, err)
+
+			err = tclient.CanSequenceOrganizationAdmin(ctx, sequenceId, MemberPrincipal(ownerId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+
+			err = tclient.CanSequenceOrganizationAdmin(ctx, sequenceId, MemberPrincipal(adminNoProductsId))
+			assert.ErrorContains(t, err, &ErrDenied{})
+		})
+
+		t.Run("organization_apikey", func(t *testing.T) {
+			err := tclient.CanSequenceOrganizationApiKey(ctx, sequenceId, ApiKeyPrincipal(apiKey))
+			assert.NoError(t, err)
+
+			err = tclient.CanSequenceOrganizationApiKey(ctx, sequenceId, ApiKeyPrincipal("other"))
+			assert.ErrorContains(t, err, &ErrDenied{})
+		})
+	})
+
+	t.Run("members_no_products", func(t *testing.T) {
+		rels := []*pb.Relationship{
+			RelationSequenceOwner(otherSequenceId, noProductsId),
+			RelationSequenceEditor(otherSequenceId, noProductsId),
+			RelationSequenceViewer(otherSequenceId, noProductsId),
+			RelationSequenceSender(otherSequenceId, noProductsId),
+		}
+		_, err := tclient.Tx().
+			Touch(RelationSequenceOrganization(otherSequenceId, orgId)).
+			Touch(rels...).
+			Commit(ctx)
+		assert.NoError(t, err)
+
+		// every member relation requires the sequences product
+		member := MemberPrincipal(noProductsId)
+		err = tclient.CanEditSequence(ctx, otherSequenceId, member)
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		err = tclient.CanViewSequence(ctx, otherSequenceId, member)
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		err = tclient.CanDeleteSequence(ctx, otherSequenceId, member)
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		err = tclient.CanUploadSequenceContact(ctx, otherSequenceId, member)
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		err = tclient.CanCreateSequenceCallStep(ctx, otherSequenceId, member)
+		assert.ErrorContains(t, err, &ErrDenied{})
+
+		sequences, err := tclient.ListSequences(ctx, member)
+		assert.NoError(t, err)
+		assert.Nil(t, sequences)
+
+		_, err = tclient.Tx().
+			Delete(RelationSequenceOrganization(otherSequenceId, orgId)).
+			Delete(rels...).
+			Commit(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		sequences, err := tclient.ListSequences(ctx, MemberPrincipal(adminNoProductsId))
+		assert.NoError(t, err)
+		assert.Nil(t, sequences)
+
+		sequences, err = tclient.ListSequences(ctx, MemberPrincipal(viewerId))
+		assert.NoError(t, err)
+		assert.Equal(t, sequences, map[string]*Sequence{
+			sequenceId: {Id: sequenceId, View: true},
+		})
+
+		sequences, err = tclient.ListSequences(ctx, MemberPrincipal(editorId))
+		assert.NoError(t, err)
+		assert.Equal(t, sequences, map[string]*Sequence{
+			sequenceId: {Id: sequenceId, View: true, Edit: true, UploadContact: true, CreateCallStep: true},
+		})
+
+		sequences, err = tclient.ListSequences(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Equal(t, sequences, map[string]*Sequence{
+			sequenceId: {Id: sequenceId, View: true, Edit: true, Delete: true, UploadContact: true, CreateCallStep: true},
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := tclient.DeleteSequenceViewer(ctx, sequenceId, viewerId)
+		assert.NoError(t, err)
+
+		_, err = tclient.DeleteSequenceEditor(ctx, sequenceId, editorId)
+		assert.NoError(t, err)
+
+		_, err = tclient.DeleteSequenceSender(ctx, sequenceId, senderId)
+		assert.NoError(t, err)
+
+		_, err = tclient.DeleteSequenceSenderTeam(ctx, sequenceId, teamId)
+		assert.NoError(t, err)
+
+		_, err = tclient.DeleteSequenceContact(ctx, sequenceId, contactId)
+		assert.NoError(t, err)
+
+		_, err = tclient.DeleteSequenceOwner(ctx, sequenceId, ownerId)
+		assert.NoError(t, err)
+
+		_, err = tclient.DeleteSequenceOrganization(ctx, sequenceId, orgId)
+		assert.NoError(t, err)
+
+		rels, err := tclient.ReadRelationships(ctx, &pb.RelationshipFilter{
+			ResourceType: definitionSequence,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, rels, 0)
+	})
+}
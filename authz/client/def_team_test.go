@@ -22,58 +22,93 @@ func TestTeam(t *testing.T) {
 
 	t.Run("organization", func(t *testing.T) {
 		t.Run("relation_apikey", func(t *testing.T) {
-			err := tclient.WriteOrganizationApiKey(ctx, orgId, apiKey)
+			_, err := tclient.WriteOrganizationApiKey(ctx, orgId, apiKey)
 			assert.NoError(t, err)
 		})
 
 		t.Run("relation_sdr", func(t *testing.T) {
-			err := tclient.WriteOrganizationSDR(ctx, orgId, sdrId)
+			_, err := tclient.WriteOrganizationSDR(ctx, orgId, sdrId)
 			assert.NoError(t, err)
 		})
 
 		t.Run("relation_admin", func(t *testing.T) {
-			err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
+			_, err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
 			assert.NoError(t, err)
 		})
 	})
 
 	t.Run("team", func(t *testing.T) {
 		t.Run("relation_organization", func(t *testing.T) {
-			err := tclient.WriteTeamOrganization(ctx, teamId, orgId)
+			_, err := tclient.WriteTeamOrganization(ctx, teamId, orgId)
 			assert.NoError(t, err)
 		})
 
 		t.Run("edit", func(t *testing.T) {
-			err := tclient.CanEditTeam(ctx, teamId, apiKey)
+			err := tclient.CanEditTeam(ctx, teamId, MemberPrincipal(apiKey))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanEditTeam(ctx, teamId, sdrId)
+			err = tclient.CanEditTeam(ctx, teamId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanEditTeam(ctx, teamId, adminId)
+			err = tclient.CanEditTeam(ctx, teamId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("view", func(t *testing.T) {
-			err := tclient.CanViewTeam(ctx, teamId, apiKey)
+			err := tclient.CanViewTeam(ctx, teamId, MemberPrincipal(apiKey))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanViewTeam(ctx, teamId, sdrId)
+			err = tclient.CanViewTeam(ctx, teamId, MemberPrincipal(sdrId))
 			assert.NoError(t, err)
 
-			err = tclient.CanViewTeam(ctx, teamId, adminId)
+			err = tclient.CanViewTeam(ctx, teamId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 
 		t.Run("delete", func(t *testing.T) {
-			err := tclient.CanDeleteTeam(ctx, teamId, apiKey)
+			err := tclient.CanDeleteTeam(ctx, teamId, MemberPrincipal(apiKey))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanDeleteTeam(ctx, teamId, sdrId)
+			err = tclient.CanDeleteTeam(ctx, teamId, MemberPrincipal(sdrId))
 			assert.ErrorContains(t, err, &ErrDenied{})
 
-			err = tclient.CanDeleteTeam(ctx, teamId, adminId)
+			err = tclient.CanDeleteTeam(ctx, teamId, MemberPrincipal(adminId))
 			assert.NoError(t, err)
 		})
 	})
+
+	t.Run("lookup", func(t *testing.T) {
+		ids, err := tclient.ListViewTeams(ctx, MemberPrincipal(sdrId))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{teamId})
+
+		ids, err = tclient.ListEditTeams(ctx, MemberPrincipal(sdrId))
+		assert.NoError(t, err)
+		assert.Len(t, ids, 0)
+
+		items, err := tclient.ListTeams(ctx, MemberPrincipal(sdrId))
+		assert.NoError(t, err)
+		assert.Equal(t, items, map[string]*Team{
+			teamId: {Id: teamId, View: true, Edit: false, Delete: false},
+		})
+
+		ids, err = tclient.ListDeleteTeams(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{teamId})
+
+		items, err = tclient.ListTeams(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Equal(t, items, map[string]*Team{
+			teamId: {Id: teamId, View: true, Edit: true, Delete: true},
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := tclient.DeleteTeamOrganization(ctx, teamId, orgId)
+		assert.NoError(t, err)
+
+		items, err := tclient.ListTeams(ctx, MemberPrincipal(adminId))
+		assert.NoError(t, err)
+		assert.Nil(t, items)
+	})
 }
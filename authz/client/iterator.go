@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+// Iterator iterates over results of a lookup or read without
+// loading all of them into memory. Results are read in pages of the
+// size set with WithPageSize, each next page is requested from the cursor
+// of the last result. The cursor may be passed with WithCursor to resume
+// the iteration later, e.g. in the next request of http pagination.
+//
+//	it := c.IterateViewContacts(ctx, memberId, WithPageSize(100))
+//	defer it.Close()
+//	for it.Next() {
+//		id := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	c      *Client
+
+	// open opens a stream of a single page, starting after the cursor.
+	open     func(ctx context.Context, cursor *pb.Cursor, limit uint32) (pageStream[T], error)
+	wrap     func(err error) error
+	pageSize uint32
+
+	next     pageStream[T]
+	received uint32 // in the current page
+	value    T
+	cursor   *pb.Cursor
+	err      error
+	done     bool
+}
+
+// pageStream returns the next result of a page and its cursor,
+// or io.EOF when the page ends.
+type pageStream[T any] func() (T, *pb.Cursor, error)
+
+func newIterator[T any](
+	ctx context.Context,
+	c *Client,
+	o *readOptions,
+	open func(ctx context.Context, cursor *pb.Cursor, limit uint32) (pageStream[T], error),
+	wrap func(err error) error,
+) *Iterator[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Iterator[T]{
+		ctx:      ctx,
+		cancel:   cancel,
+		c:        c,
+		open:     open,
+		wrap:     wrap,
+		pageSize: o.pageSize,
+		cursor:   o.cursor,
+	}
+}
+
+// newErrIterator returns an iterator which stops with err
+// before the first result.
+func newErrIterator[T any](err error) *Iterator[T] {
+	return &Iterator[T]{err: err, done: true}
+}
+
+// Next advances the iterator to the next result.
+// It returns false when there are no more results or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.done {
+		return false
+	}
+
+	var (
+		value  T
+		cursor *pb.Cursor
+		err    error
+	)
+	if it.next == nil {
+		// a page is retried until it returns the first result,
+		// later errors are final, see Client.retry
+		err = it.c.retry(it.ctx, func() (err error) {
+			it.next, err = it.open(it.ctx, it.cursor, it.pageSize)
+			if err != nil {
+				return err
+			}
+			value, cursor, err = it.next()
+			return err
+		})
+	} else {
+		value, cursor, err = it.next()
+	}
+
+	switch {
+	case errors.Is(err, io.EOF) && it.pageSize > 0 && it.received == it.pageSize:
+		// full page, there may be more results
+		it.next, it.received = nil, 0
+		return it.Next()
+	case errors.Is(err, io.EOF):
+		it.finish(nil)
+		return false
+	case err != nil:
+		it.finish(it.wrap(err))
+		return false
+	}
+
+	it.received++
+	it.value, it.cursor = value, cursor
+	return true
+}
+
+// Value returns the current result.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Cursor returns the cursor of the current result. Iteration resumed
+// from it with WithCursor starts from the next result.
+// It's empty if Next has not returned any result yet.
+func (it *Iterator[T]) Cursor() string {
+	return it.cursor.GetToken()
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and releases the stream.
+// It should be called if the iteration ends early.
+func (it *Iterator[T]) Close() {
+	it.finish(nil)
+}
+
+func (it *Iterator[T]) finish(err error) {
+	if it.done {
+		return
+	}
+	it.done = true
+	it.err = err
+	it.cancel()
+}
+
+func (c *Client) iterateResources(
+	ctx context.Context,
+	req *pb.LookupResourcesRequest,
+	o *readOptions,
+) *Iterator[string] {
+	open := func(ctx context.Context, cursor *pb.Cursor, limit uint32) (pageStream[string], error) {
+		page := &pb.LookupResourcesRequest{
+			Consistency:        req.Consistency,
+			ResourceObjectType: req.ResourceObjectType,
+			Permission:         req.Permission,
+			Subject:            req.Subject,
+			Context:            req.Context,
+			OptionalLimit:      limit,
+			OptionalCursor:     cursor,
+		}
+		stream, err := c.c.LookupResources(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		return func() (string, *pb.Cursor, error) {
+			resp, err := stream.Recv()
+			if err != nil {
+				return "", nil, err
+			}
+			return resp.ResourceObjectId, resp.AfterResultCursor, nil
+		}, nil
+	}
+	wrap := func(err error) error {
+		return fmt.Errorf("authz: lookup resources %q: %w", relstr(req), err)
+	}
+	return newIterator(ctx, c, o, open, wrap)
+}
+
+// IterateRelationships iterates over relationships matching the filter.
+// See Iterator for paging and resuming the iteration.
+func (c *Client) IterateRelationships(
+	ctx context.Context,
+	req *pb.RelationshipFilter,
+	opts ...ReadOption,
+) *Iterator[*pb.Relationship] {
+	consistency := c.consistency(ctx, opts...)
+	open := func(ctx context.Context, cursor *pb.Cursor, limit uint32) (pageStream[*pb.Relationship], error) {
+		stream, err := c.c.ReadRelationships(ctx, &pb.ReadRelationshipsRequest{
+			Consistency:        consistency,
+			RelationshipFilter: req,
+			OptionalLimit:      limit,
+			OptionalCursor:     cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return func() (*pb.Relationship, *pb.Cursor, error) {
+			resp, err := stream.Recv()
+			if err != nil {
+				return nil, nil, err
+			}
+			return resp.Relationship, resp.AfterResultCursor, nil
+		}, nil
+	}
+	wrap := func(err error) error {
+		return fmt.Errorf("authz: read relationships: %w", err)
+	}
+	return newIterator(ctx, c, newReadOptions(opts...), open, wrap)
+}
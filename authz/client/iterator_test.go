@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/grpc"
+)
+
+func TestIterator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streams := 0
+	tclient, err := StartTestServer(ctx, WithStreamInterceptors(
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			streams++
+			return streamer(ctx, desc, cc, method, opts...)
+		},
+	))
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	memberId := "alice"
+
+	_, err = tclient.WriteOrganizationAdmin(ctx, orgId, memberId)
+	assert.NoError(t, err)
+
+	var offDayIds []string
+	for i := 0; i < 25; i++ {
+		offDayId := fmt.Sprintf("offday%02d", i)
+		offDayIds = append(offDayIds, offDayId)
+
+		_, err := tclient.WriteOffDayOrganization(ctx, offDayId, orgId)
+		assert.NoError(t, err)
+	}
+
+	collect := func(it *Iterator[string]) []string {
+		defer it.Close()
+
+		var ids []string
+		for it.Next() {
+			ids = append(ids, it.Value())
+		}
+		assert.NoError(t, it.Err())
+		sort.Strings(ids)
+		return ids
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		streams = 0
+		ids := collect(tclient.IterateViewOffDays(ctx, MemberPrincipal(memberId), WithPageSize(10)))
+		assert.Equal(t, ids, offDayIds)
+		assert.Equal(t, streams, 3)
+	})
+
+	t.Run("single_page", func(t *testing.T) {
+		streams = 0
+		ids := collect(tclient.IterateViewOffDays(ctx, MemberPrincipal(memberId)))
+		assert.Equal(t, ids, offDayIds)
+		assert.Equal(t, streams, 1)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		it := tclient.IterateViewOffDays(ctx, MemberPrincipal(memberId), WithPageSize(10))
+		var ids []string
+		for len(ids) < 10 && it.Next() {
+			ids = append(ids, it.Value())
+		}
+		cursor := it.Cursor()
+		it.Close()
+		assert.NoError(t, it.Err())
+		assert.True(t, cursor != "")
+
+		ids = append(ids, collect(tclient.IterateViewOffDays(ctx, MemberPrincipal(memberId), WithPageSize(10), WithCursor(cursor)))...)
+		sort.Strings(ids)
+		assert.Equal(t, ids, offDayIds)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		it := tclient.IterateViewOffDays(ctx, MemberPrincipal(memberId))
+		assert.True(t, it.Next())
+
+		it.Close()
+		assert.True(t, !it.Next())
+		assert.NoError(t, it.Err())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		ids := collect(tclient.IterateViewOffDays(ctx, MemberPrincipal("bob"), WithPageSize(10)))
+		assert.Len(t, ids, 0)
+	})
+
+	t.Run("relationships", func(t *testing.T) {
+		filter := &pb.RelationshipFilter{ResourceType: definitionOffDay}
+
+		it := tclient.IterateRelationships(ctx, filter, WithPageSize(7))
+		defer it.Close()
+
+		count := 0
+		for it.Next() {
+			assert.Equal(t, it.Value().Subject.Object.ObjectId, orgId)
+			count++
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, count, len(offDayIds))
+	})
+}
@@ -0,0 +1,240 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// schemaRelation is a relation of the schema with a single subject type,
+// see schemaRelations.
+type schemaRelation struct {
+	definition string
+	relation   string
+	subject    string
+}
+
+// transferRelations are relations transferred to the new member
+// on offboarding, other relations of the member are deleted.
+var transferRelations = []string{relationOwner, relationAssignee}
+
+// OffboardOption configures OffboardMember.
+type OffboardOption func(*offboardOptions)
+
+type offboardOptions struct {
+	transferTo string
+	dryRun     bool
+}
+
+// WithTransferTo transfers ownership of contacts, inboxes, sequences
+// and meetings, and assigned sequence actions to the member.
+// Without it they are deleted together with other relationships.
+func WithTransferTo(memberId string) OffboardOption {
+	return func(o *offboardOptions) {
+		o.transferTo = memberId
+	}
+}
+
+// WithDryRun only reports the changes, nothing is written.
+func WithDryRun() OffboardOption {
+	return func(o *offboardOptions) {
+		o.dryRun = true
+	}
+}
+
+// OffboardReport reports changes made by OffboardMember.
+type OffboardReport struct {
+	// Deleted are all relationships of the offboarded member.
+	Deleted []*pb.Relationship
+
+	// Transferred are relationships written for the member
+	// set with WithTransferTo.
+	Transferred []*pb.Relationship
+
+	// WrittenAt is the token of the write, it's nil on dry run.
+	WrittenAt *pb.ZedToken
+}
+
+// OffboardMember deletes all relationships whose subject is the member,
+// e.g. organization roles, sequence editors or action assignees,
+// and optionally transfers ownership to another member, see WithTransferTo.
+// The member is removed from every organization they belong to.
+// All changes are written in a single transaction, so a member with
+// more relationships than the server accepts in a single write
+// must be offboarded manually.
+//
+// Transfers are grouped by the organization of the transferred resource,
+// which must be one of the member's organizations. The transfer fails
+// with ErrPreconditionFailed if the new member is not an admin or SDR
+// of each of these organizations.
+func (c *Client) OffboardMember(
+	ctx context.Context,
+	memberId string,
+	opts ...OffboardOption,
+) (*OffboardReport, error) {
+	o := &offboardOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.transferTo == memberId {
+		return nil, errors.New("authz: offboard member: transfer to the offboarded member")
+	}
+
+	rels, err := c.subjectRelationships(ctx, subRef(definitionMember, memberId))
+	if err != nil {
+		return nil, fmt.Errorf("authz: offboard member %q: %w", memberId, err)
+	}
+
+	report := &OffboardReport{Deleted: rels}
+	tx := c.Tx().Delete(rels...)
+	if o.transferTo != "" {
+		transfers, err := c.transfersByOrganization(ctx, rels)
+		if err != nil {
+			return nil, fmt.Errorf("authz: offboard member %q: %w", memberId, err)
+		}
+
+		orgIds := make([]string, 0, len(transfers))
+		for orgId := range transfers {
+			orgIds = append(orgIds, orgId)
+		}
+		slices.Sort(orgIds)
+
+		// a resource of several organizations is transferred once,
+		// the same relationship can't be updated twice in a request
+		seen := make(map[string]bool)
+		for _, orgId := range orgIds {
+			for _, rel := range transfers[orgId] {
+				transferred := proto.Clone(rel).(*pb.Relationship)
+				transferred.Subject = subRef(definitionMember, o.transferTo)
+				if key := relstr(transferred); !seen[key] {
+					seen[key] = true
+					report.Transferred = append(report.Transferred, transferred)
+					tx.Touch(transferred)
+				}
+			}
+
+			// admin or sdr, the only relations of organization with members
+			tx.MustExist(&pb.RelationshipFilter{
+				ResourceType:       definitionOrganization,
+				OptionalResourceId: orgId,
+				OptionalSubjectFilter: &pb.SubjectFilter{
+					SubjectType:       definitionMember,
+					OptionalSubjectId: o.transferTo,
+				},
+			})
+		}
+	}
+
+	if o.dryRun || len(rels) == 0 {
+		return report, nil
+	}
+
+	report.WrittenAt, err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// transfersByOrganization groups relationships of transferRelations
+// by the organization of their resource. The organization must be
+// one of the organizations of the member, i.e. the resource of another
+// relationship in rels.
+func (c *Client) transfersByOrganization(
+	ctx context.Context,
+	rels []*pb.Relationship,
+) (map[string][]*pb.Relationship, error) {
+	memberOrgs := make(map[string]bool)
+	for _, rel := range rels {
+		if rel.Resource.ObjectType == definitionOrganization {
+			memberOrgs[rel.Resource.ObjectId] = true
+		}
+	}
+
+	transfers := make(map[string][]*pb.Relationship)
+	for _, rel := range rels {
+		if !slices.Contains(transferRelations, rel.Relation) {
+			continue
+		}
+
+		orgIds, err := c.resourceOrganizations(ctx, rel.Resource)
+		if err != nil {
+			return nil, err
+		}
+		if len(orgIds) == 0 {
+			return nil, fmt.Errorf("transfer %q: resource doesn't belong to any organization", relstr(rel))
+		}
+		for _, orgId := range orgIds {
+			if !memberOrgs[orgId] {
+				return nil, fmt.Errorf("transfer %q: organization %q is not an organization of the member", relstr(rel), orgId)
+			}
+			transfers[orgId] = append(transfers[orgId], rel)
+		}
+	}
+	return transfers, nil
+}
+
+// resourceOrganizations reads organizations of the resource,
+// sequence actions belong to organizations of their sequence.
+func (c *Client) resourceOrganizations(ctx context.Context, resource *pb.ObjectReference) ([]string, error) {
+	relation := relationOrganization
+	if resource.ObjectType == definitionSequenceAction {
+		relation = relationSequence
+	}
+
+	rels, err := c.ReadRelationships(ctx, &pb.RelationshipFilter{
+		ResourceType:       resource.ObjectType,
+		OptionalResourceId: resource.ObjectId,
+		OptionalRelation:   relation,
+	}, FullyConsistent())
+	if err != nil {
+		return nil, err
+	}
+
+	var orgIds []string
+	for _, rel := range rels {
+		if relation == relationOrganization {
+			orgIds = append(orgIds, rel.Subject.Object.ObjectId)
+			continue
+		}
+
+		seqOrgIds, err := c.resourceOrganizations(ctx, rel.Subject.Object)
+		if err != nil {
+			return nil, err
+		}
+		orgIds = append(orgIds, seqOrgIds...)
+	}
+	return orgIds, nil
+}
+
+// subjectRelationships reads relationships of the subject in all relations
+// of the schema which accept the subject type.
+func (c *Client) subjectRelationships(
+	ctx context.Context,
+	subject *pb.SubjectReference,
+) ([]*pb.Relationship, error) {
+	var rels []*pb.Relationship
+	for _, r := range schemaRelations {
+		if r.subject != subject.Object.ObjectType {
+			continue
+		}
+
+		found, err := c.ReadRelationships(ctx, &pb.RelationshipFilter{
+			ResourceType:     r.definition,
+			OptionalRelation: r.relation,
+			OptionalSubjectFilter: &pb.SubjectFilter{
+				SubjectType:       subject.Object.ObjectType,
+				OptionalSubjectId: subject.Object.ObjectId,
+			},
+		}, FullyConsistent())
+		if err != nil {
+			return nil, err
+		}
+		rels = append(rels, found...)
+	}
+	return rels, nil
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+func TestOffboardMember(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	memberId := "bob"
+	newOwnerId := "alice"
+	otherOrgId := "other"
+	otherOwnerId := "carol"
+
+	relstrs := func(rels []*pb.Relationship) []string {
+		strs := make([]string, len(rels))
+		for i, rel := range rels {
+			// caveat context is formatted with protojson,
+			// which randomly adds spaces to the output
+			strs[i] = strings.ReplaceAll(tuple.MustStringRelationship(rel), " ", "")
+		}
+		sort.Strings(strs)
+		return strs
+	}
+
+	memberRels := func(t *testing.T, memberId string) []string {
+		rels, err := tclient.subjectRelationships(ctx, subRef(definitionMember, memberId))
+		assert.NoError(t, err)
+		return relstrs(rels)
+	}
+
+	_, err = tclient.Tx().Touch(
+		RelationOrganizationAdmin(orgId, newOwnerId, ProductSequences),
+		RelationOrganizationSDR(orgId, memberId, ProductSequences),
+		RelationOrganizationAdmin(otherOrgId, otherOwnerId),
+		RelationContactOrganization("contact", orgId),
+		RelationContactOwner("contact", memberId),
+		RelationSequenceOrganization("sequence", orgId),
+		RelationSequenceOwner("sequence", memberId),
+		RelationSequenceEditor("sequence", memberId),
+		RelationSequenceSender("sequence", memberId),
+		RelationSequenceActionSequence("action", "sequence"),
+		RelationSequenceActionAssignee("action", memberId),
+		RelationMeetingOrganization("meeting", orgId),
+		RelationMeetingOwner("meeting", memberId),
+	).Commit(ctx)
+	assert.NoError(t, err)
+
+	wantDeleted := []string{
+		"contact:contact#owner@member:bob",
+		"meeting:meeting#owner@member:bob",
+		`organization:rift#sdr@member:bob[products:{"enabled":["sequences"]}]`,
+		"sequence/action:action#assignee@member:bob",
+		"sequence:sequence#editor@member:bob",
+		"sequence:sequence#owner@member:bob",
+		"sequence:sequence#sender@member:bob",
+	}
+	wantTransferred := []string{
+		"contact:contact#owner@member:alice",
+		"meeting:meeting#owner@member:alice",
+		"sequence/action:action#assignee@member:alice",
+		"sequence:sequence#owner@member:alice",
+	}
+
+	t.Run("dry_run", func(t *testing.T) {
+		report, err := tclient.OffboardMember(ctx, memberId, WithTransferTo(newOwnerId), WithDryRun())
+		assert.NoError(t, err)
+		assert.Equal(t, relstrs(report.Deleted), wantDeleted)
+		assert.Equal(t, relstrs(report.Transferred), wantTransferred)
+		assert.Nil(t, report.WrittenAt)
+
+		assert.Equal(t, memberRels(t, memberId), wantDeleted)
+	})
+
+	t.Run("unknown_member", func(t *testing.T) {
+		_, err := tclient.OffboardMember(ctx, memberId, WithTransferTo("nobody"))
+		assert.True(t, errors.Is(err, ErrPreconditionFailed))
+		assert.Equal(t, memberRels(t, memberId), wantDeleted)
+	})
+
+	t.Run("other_organization", func(t *testing.T) {
+		// the new member must belong to the organization of the resources
+		_, err := tclient.OffboardMember(ctx, memberId, WithTransferTo(otherOwnerId))
+		assert.True(t, errors.Is(err, ErrPreconditionFailed))
+		assert.Equal(t, memberRels(t, memberId), wantDeleted)
+		assert.Len(t, memberRels(t, otherOwnerId), 1)
+	})
+
+	t.Run("foreign_resource", func(t *testing.T) {
+		// resources of other organizations are never transferred
+		_, err := tclient.Tx().Touch(
+			RelationInboxOrganization("inbox", otherOrgId),
+			RelationInboxOwner("inbox", memberId),
+		).Commit(ctx)
+		assert.NoError(t, err)
+
+		_, err = tclient.OffboardMember(ctx, memberId, WithTransferTo(newOwnerId))
+		assert.ErrorContains(t, err, `organization "other" is not an organization of the member`)
+
+		_, err = tclient.Tx().Delete(RelationInboxOwner("inbox", memberId)).Commit(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("self", func(t *testing.T) {
+		_, err := tclient.OffboardMember(ctx, memberId, WithTransferTo(memberId))
+		assert.ErrorContains(t, err, "transfer to the offboarded member")
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		report, err := tclient.OffboardMember(ctx, memberId, WithTransferTo(newOwnerId))
+		assert.NoError(t, err)
+		assert.True(t, report.WrittenAt != nil)
+		assert.Len(t, memberRels(t, memberId), 0)
+
+		assert.Equal(t, memberRels(t, newOwnerId), []string{
+			"contact:contact#owner@member:alice",
+			"meeting:meeting#owner@member:alice",
+			`organization:rift#admin@member:alice[products:{"enabled":["sequences"]}]`,
+			"sequence/action:action#assignee@member:alice",
+			"sequence:sequence#owner@member:alice",
+		})
+
+		err = tclient.CanDeleteSequence(ctx, "sequence", MemberPrincipal(newOwnerId), AtLeastAsFresh(report.WrittenAt))
+		assert.NoError(t, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		report, err := tclient.OffboardMember(ctx, newOwnerId)
+		assert.NoError(t, err)
+		assert.Len(t, report.Deleted, 5)
+		assert.Len(t, report.Transferred, 0)
+		assert.Len(t, memberRels(t, newOwnerId), 0)
+	})
+
+	t.Run("two_organizations", func(t *testing.T) {
+		// the contact belongs to both organizations of the member
+		_, err := tclient.Tx().Touch(
+			RelationOrganizationSDR(orgId, "dan"),
+			RelationOrganizationSDR(otherOrgId, "dan"),
+			RelationOrganizationAdmin(orgId, "erin"),
+			RelationOrganizationAdmin(otherOrgId, "erin"),
+			RelationContactOrganization("shared", orgId),
+			RelationContactOrganization("shared", otherOrgId),
+			RelationContactOwner("shared", "dan"),
+		).Commit(ctx)
+		assert.NoError(t, err)
+
+		report, err := tclient.OffboardMember(ctx, "dan", WithTransferTo("erin"))
+		assert.NoError(t, err)
+		assert.Equal(t, relstrs(report.Transferred), []string{"contact:shared#owner@member:erin"})
+		assert.Len(t, memberRels(t, "dan"), 0)
+
+		rels, err := tclient.ReadRelationships(ctx, &pb.RelationshipFilter{
+			ResourceType:       definitionContact,
+			OptionalResourceId: "shared",
+			OptionalRelation:   relationOwner,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, relstrs(rels), []string{"contact:shared#owner@member:erin"})
+	})
+}
@@ -0,0 +1,282 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"time"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/grpcutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Option configures the client created with NewWithOptions.
+type Option func(*options) error
+
+type options struct {
+	secret   string
+	insecure bool
+	tls      *tls.Config
+
+	timeout            time.Duration
+	retry              RetryPolicy
+	cacheSize          int
+	cacheTTL           time.Duration
+	keepalive          *keepalive.ClientParameters
+	userAgent          string
+	dialOptions        []grpc.DialOption
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+}
+
+func newOptions(opts ...Option) (*options, error) {
+	o := &options{
+		tls:   &tls.Config{MinVersion: tls.VersionTLS12},
+		retry: DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// WithBearerToken authenticates requests with the spicedb preshared key.
+func WithBearerToken(secret string) Option {
+	return func(o *options) error {
+		o.secret = secret
+		return nil
+	}
+}
+
+// WithInsecure disables transport security.
+// NOTE: it should be used only in local development and tests.
+func WithInsecure() Option {
+	return func(o *options) error {
+		o.insecure = true
+		return nil
+	}
+}
+
+// WithCACert verifies the server certificate with the given PEM encoded
+// CA certificates instead of the system ones.
+func WithCACert(pem []byte) Option {
+	return func(o *options) error {
+		if o.tls.RootCAs == nil {
+			o.tls.RootCAs = x509.NewCertPool()
+		}
+		if !o.tls.RootCAs.AppendCertsFromPEM(pem) {
+			return errors.New("authz: invalid ca certificate")
+		}
+		return nil
+	}
+}
+
+// WithClientCert authenticates the client with the given PEM encoded
+// certificate and key (mTLS).
+func WithClientCert(certPEM, keyPEM []byte) Option {
+	return func(o *options) error {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return err
+		}
+		o.tls.Certificates = append(o.tls.Certificates, cert)
+		return nil
+	}
+}
+
+// WithTLSConfig replaces the tls config, e.g. to set server name.
+// Options WithCACert and WithClientCert given after it modify the config.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(o *options) error {
+		if cfg == nil {
+			return errors.New("authz: nil tls config")
+		}
+		o.tls = cfg.Clone()
+		return nil
+	}
+}
+
+// WithTimeout sets the default deadline of calls without one.
+// For streams, e.g. lookups, the deadline covers the whole stream.
+// The watch stream is long-lived, so it has no default deadline.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) error {
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithRetry replaces the default retry policy of transient errors.
+// Use RetryPolicy{} to disable retries.
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) error {
+		o.retry = policy
+		return nil
+	}
+}
+
+// WithCheckCache caches results of up to size permission checks
+// for the ttl, see WatchCheckCache for the invalidation of the cache.
+// Checks which require fresher data than the cached result bypass it.
+// The cache is bypassed in builds with the authzdebug tag,
+// so every denial carries its trace.
+func WithCheckCache(size int, ttl time.Duration) Option {
+	return func(o *options) error {
+		if size <= 0 || ttl <= 0 {
+			return errors.New("authz: check cache size and ttl must be positive")
+		}
+		o.cacheSize = size
+		o.cacheTTL = ttl
+		return nil
+	}
+}
+
+// WithKeepalive sets keepalive parameters of the connection.
+func WithKeepalive(params keepalive.ClientParameters) Option {
+	return func(o *options) error {
+		o.keepalive = &params
+		return nil
+	}
+}
+
+// WithUserAgent sets user agent sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) error {
+		o.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithDialOptions appends grpc dial options.
+// They are applied last, so they override the other options.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) error {
+		o.dialOptions = append(o.dialOptions, opts...)
+		return nil
+	}
+}
+
+// WithUnaryInterceptors appends unary client interceptors.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(o *options) error {
+		o.unaryInterceptors = append(o.unaryInterceptors, interceptors...)
+		return nil
+	}
+}
+
+// WithStreamInterceptors appends stream client interceptors.
+func WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) Option {
+	return func(o *options) error {
+		o.streamInterceptors = append(o.streamInterceptors, interceptors...)
+		return nil
+	}
+}
+
+// transportOptions returns dial options of the transport security
+// and authentication.
+func (o *options) transportOptions() []grpc.DialOption {
+	if o.insecure {
+		opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+		if o.secret != "" {
+			opts = append(opts, grpcutil.WithInsecureBearerToken(o.secret))
+		}
+		return opts
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(o.tls))}
+	if o.secret != "" {
+		opts = append(opts, grpcutil.WithBearerToken(o.secret))
+	}
+	return opts
+}
+
+// callOptions returns dial options of the calls, i.e. all options
+// but the transport ones.
+func (o *options) callOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+
+	// timeout interceptors go first, so other interceptors
+	// see the default deadline
+	unary := o.unaryInterceptors
+	stream := o.streamInterceptors
+	if o.timeout > 0 {
+		unary = append([]grpc.UnaryClientInterceptor{timeoutUnaryInterceptor(o.timeout)}, unary...)
+		stream = append([]grpc.StreamClientInterceptor{timeoutStreamInterceptor(o.timeout)}, stream...)
+	}
+	if len(unary) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(unary...))
+	}
+	if len(stream) > 0 {
+		opts = append(opts, grpc.WithChainStreamInterceptor(stream...))
+	}
+
+	if o.keepalive != nil {
+		opts = append(opts, grpc.WithKeepaliveParams(*o.keepalive))
+	}
+	if o.userAgent != "" {
+		opts = append(opts, grpc.WithUserAgent(o.userAgent))
+	}
+	return append(opts, o.dialOptions...)
+}
+
+func timeoutUnaryInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func timeoutStreamInterceptor(timeout time.Duration) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		if _, ok := ctx.Deadline(); ok || method == pb.WatchService_Watch_FullMethodName {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		return &timeoutStream{ClientStream: stream, cancel: cancel}, nil
+	}
+}
+
+// timeoutStream releases the timeout context when the stream ends.
+type timeoutStream struct {
+	grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+func (s *timeoutStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.cancel()
+	}
+	return err
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestOptions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("mem_server", func(t *testing.T) {
+		var (
+			mx        sync.Mutex
+			deadlines = make(map[string]bool)
+		)
+		record := func(ctx context.Context, method string) {
+			_, ok := ctx.Deadline()
+			mx.Lock()
+			deadlines[method] = ok
+			mx.Unlock()
+		}
+
+		tclient, err := StartTestServer(ctx,
+			WithTimeout(time.Minute),
+			WithUserAgent("rift-test"),
+			WithUnaryInterceptors(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				record(ctx, method)
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}),
+			WithStreamInterceptors(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				record(ctx, method)
+				return streamer(ctx, desc, cc, method, opts...)
+			}),
+		)
+		assert.NoError(t, err)
+
+		_, err = tclient.WriteOffDayOrganization(ctx, "offday", "org")
+		assert.NoError(t, err)
+
+		_, err = tclient.ListViewOffDays(ctx, MemberPrincipal("member"))
+		assert.NoError(t, err)
+
+		assert.Equal(t, deadlines, map[string]bool{
+			"/authzed.api.v1.SchemaService/WriteSchema":             true,
+			"/authzed.api.v1.PermissionsService/WriteRelationships": true,
+			"/authzed.api.v1.PermissionsService/LookupResources":    true,
+		})
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		ca, caPEM := newTestCert(t, nil, "ca")
+		_, serverPEM := newTestCert(t, ca, "server")
+		_, clientPEM := newTestCert(t, ca, "client")
+
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(caPEM.cert)
+		serverCert, err := tls.X509KeyPair(serverPEM.cert, serverPEM.key)
+		assert.NoError(t, err)
+
+		srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{serverCert},
+			ClientCAs:    pool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		})))
+		pb.RegisterSchemaServiceServer(srv, &schemaServer{secret: "secret", userAgent: "rift-test"})
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		go func() { _ = srv.Serve(lis) }()
+		defer srv.Stop()
+
+		c, err := NewWithOptions(lis.Addr().String(),
+			WithCACert(caPEM.cert),
+			WithClientCert(clientPEM.cert, clientPEM.key),
+			WithBearerToken("secret"),
+			WithUserAgent("rift-test"),
+		)
+		assert.NoError(t, err)
+
+		schema, err := c.ReadSchema(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, schema, "definition user {}")
+
+		// server certificate is not trusted
+		c, err = NewWithOptions(lis.Addr().String(),
+			WithClientCert(clientPEM.cert, clientPEM.key),
+			WithBearerToken("secret"),
+		)
+		assert.NoError(t, err)
+
+		_, err = c.ReadSchema(ctx)
+		assert.ErrorContains(t, err, "certificate")
+
+		// client certificate is missing
+		c, err = NewWithOptions(lis.Addr().String(),
+			WithCACert(caPEM.cert),
+			WithBearerToken("secret"),
+		)
+		assert.NoError(t, err)
+
+		_, err = c.ReadSchema(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewWithOptions("localhost:50051", WithCACert([]byte("invalid")))
+		assert.ErrorContains(t, err, "invalid ca certificate")
+
+		_, err = NewWithOptions("localhost:50051", WithClientCert([]byte("invalid"), []byte("invalid")))
+		assert.Error(t, err)
+
+		_, err = NewWithOptions("localhost:50051", WithTLSConfig(nil), WithCACert(nil))
+		assert.ErrorContains(t, err, "nil tls config")
+	})
+}
+
+// schemaServer is a stub spicedb server, which checks authentication
+// and user agent of the client.
+type schemaServer struct {
+	pb.UnimplementedSchemaServiceServer
+
+	secret    string
+	userAgent string
+}
+
+func (s *schemaServer) ReadSchema(ctx context.Context, req *pb.ReadSchemaRequest) (*pb.ReadSchemaResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if auth := md.Get("authorization"); len(auth) == 0 || auth[0] != "Bearer "+s.secret {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	if ua := md.Get("user-agent"); len(ua) == 0 || !strings.HasPrefix(ua[0], s.userAgent) {
+		return nil, status.Error(codes.InvalidArgument, "invalid user agent")
+	}
+	return &pb.ReadSchemaResponse{SchemaText: "definition user {}"}, nil
+}
+
+type testPEM struct {
+	cert []byte
+	key  []byte
+}
+
+// newTestCert creates a certificate signed by the parent,
+// or a self-signed CA certificate if the parent is nil.
+func newTestCert(t *testing.T, parent *tls.Certificate, name string) (*tls.Certificate, testPEM) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	signer, signerKey := tmpl, any(key)
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.Leaf, parent.PrivateKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	assert.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	p := testPEM{
+		cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+
+	cert, err := tls.X509KeyPair(p.cert, p.key)
+	assert.NoError(t, err)
+	cert.Leaf, err = x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &cert, p
+}
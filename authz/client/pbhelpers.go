@@ -38,10 +38,26 @@ func fullConsistency() *pb.Consistency {
 	}
 }
 
+func minimizeLatency() *pb.Consistency {
+	return &pb.Consistency{
+		Requirement: &pb.Consistency_MinimizeLatency{
+			MinimizeLatency: true,
+		},
+	}
+}
+
+func atLeastAsFresh(token *pb.ZedToken) *pb.Consistency {
+	return &pb.Consistency{
+		Requirement: &pb.Consistency_AtLeastAsFresh{
+			AtLeastAsFresh: token,
+		},
+	}
+}
+
 // relstr converts different spicedb structs to string relation.
 // It is used to return a clear error message.
 func relstr[
-	R *pb.CheckPermissionRequest | *pb.LookupResourcesRequest | *pb.Relationship,
+	R *pb.CheckPermissionRequest | *pb.LookupResourcesRequest | *pb.LookupSubjectsRequest | *pb.Relationship,
 ](r R) string {
 	switch r := (any)(r).(type) {
 	case *pb.CheckPermissionRequest:
@@ -71,6 +87,19 @@ func relstr[
 				},
 			},
 		})
+	case *pb.LookupSubjectsRequest:
+		return tuple.MustStringRelationship(&pb.Relationship{
+			Resource: &pb.ObjectReference{
+				ObjectType: r.Resource.ObjectType,
+				ObjectId:   r.Resource.ObjectId,
+			},
+			Relation: r.Permission,
+			Subject: &pb.SubjectReference{
+				Object: &pb.ObjectReference{
+					ObjectType: r.SubjectObjectType,
+				},
+			},
+		})
 	case *pb.Relationship:
 		return tuple.MustStringRelationship(r)
 	}
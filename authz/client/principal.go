@@ -0,0 +1,51 @@
+package client
+
+import (
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+// Principal is the subject of permission checks and lookups,
+// e.g. the member using the app or the api key of the public api.
+// Both go through the same checks, the schema decides what they can do.
+type Principal struct {
+	typ string
+	id  string
+}
+
+// MemberPrincipal is the principal of the organization member.
+func MemberPrincipal(memberId string) Principal {
+	return Principal{typ: definitionMember, id: memberId}
+}
+
+// ApiKeyPrincipal is the principal of the organization api key.
+func ApiKeyPrincipal(apiKeyId string) Principal {
+	return Principal{typ: definitionApiKey, id: apiKeyId}
+}
+
+// UserPrincipal is the principal of the platform user.
+func UserPrincipal(userId string) Principal {
+	return Principal{typ: definitionUser, id: userId}
+}
+
+// TeamPrincipal is the principal of the organization team.
+func TeamPrincipal(teamId string) Principal {
+	return Principal{typ: definitionTeam, id: teamId}
+}
+
+// Type returns the schema definition of the principal, e.g. member.
+func (p Principal) Type() string {
+	return p.typ
+}
+
+// ID returns the id of the principal.
+func (p Principal) ID() string {
+	return p.id
+}
+
+func (p Principal) String() string {
+	return p.typ + ":" + p.id
+}
+
+func (p Principal) ref() *pb.SubjectReference {
+	return subRef(p.typ, p.id)
+}
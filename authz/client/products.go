@@ -0,0 +1,324 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Product is a paid feature which can be enabled for an organization member.
+// Enabled products are stored in the products caveat context of the member
+// role, and permissions may require some of them to be enabled.
+type Product string
+
+const (
+	ProductSequences Product = "sequences"
+	ProductMeetings  Product = "meetings"
+	ProductWarmer    Product = "warmer"
+	ProductCalls     Product = "calls"
+)
+
+// productRequirement describes which products must be enabled
+// to be granted a permission.
+type productRequirement struct {
+	products []Product
+	// oneOf if true, then at least one of the products needs to be enabled,
+	// otherwise all of them need to be enabled.
+	oneOf bool
+}
+
+// productRequirements is a registry of products required by permissions,
+// keyed by definition and permission. It reflects the "required - ..."
+// comments in the schema. Permissions missing from the registry
+// do not require any product.
+var productRequirements = map[string]map[string]productRequirement{
+	definitionOrganization: {
+		permissionCreateSequence: {products: []Product{ProductSequences}},
+		permissionCreateInbox:    {products: []Product{ProductSequences, ProductWarmer}, oneOf: true},
+		permissionCreateMeeting:  {products: []Product{ProductMeetings}},
+	},
+	// inbox is used by sequences and warmer, so one of them is enough
+	definitionInbox: {
+		permissionEdit:   {products: []Product{ProductSequences, ProductWarmer}, oneOf: true},
+		permissionView:   {products: []Product{ProductSequences, ProductWarmer}, oneOf: true},
+		permissionDelete: {products: []Product{ProductSequences, ProductWarmer}, oneOf: true},
+	},
+	// every permission check requires sequences product to be enabled
+	definitionSequence: {
+		permissionEdit:               {products: []Product{ProductSequences}},
+		permissionView:               {products: []Product{ProductSequences}},
+		permissionDelete:             {products: []Product{ProductSequences}},
+		permissionUploadContact:      {products: []Product{ProductSequences}},
+		permissionCreateCallStep:     {products: []Product{ProductSequences, ProductCalls}},
+		permissionOrganizationAdmin:  {products: []Product{ProductSequences}},
+		permissionOrganizationApiKey: {products: []Product{ProductSequences}},
+		permissionOrganizationAccess: {products: []Product{ProductSequences}},
+	},
+	// sequence actions are part of sequences and their view permission
+	// walks through sequence permissions
+	definitionSequenceAction: {
+		permissionEdit: {products: []Product{ProductSequences}},
+		permissionView: {products: []Product{ProductSequences}},
+	},
+	// every permission check requires meetings product to be enabled
+	definitionMeeting: {
+		permissionEdit:   {products: []Product{ProductMeetings}},
+		permissionView:   {products: []Product{ProductMeetings}},
+		permissionDelete: {products: []Product{ProductMeetings}},
+	},
+}
+
+// newCaveatProductsRequired builds the products caveat context
+// required to check the permission of the given definition.
+func newCaveatProductsRequired(definition string, permission string) *structpb.Struct {
+	req := productRequirements[definition][permission]
+	return newCaveatProductsCheck(productsToStrings(req.products), req.oneOf)
+}
+
+func productsToStrings(products []Product) []string {
+	out := make([]string, len(products))
+	for i, p := range products {
+		out[i] = string(p)
+	}
+	return out
+}
+
+// GetOrganizationMemberProducts returns products enabled for the member
+// of the organization. The products are read from the caveat context
+// of the member role.
+func (c *Client) GetOrganizationMemberProducts(
+	ctx context.Context,
+	organizationId string,
+	memberId string,
+	opts ...ReadOption,
+) ([]Product, error) {
+	role, err := c.readOrganizationRole(ctx, organizationId, memberId, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return enabledProducts(role), nil
+}
+
+// SetOrganizationMemberProducts replaces products enabled for the member
+// of the organization, keeping the member role unchanged.
+//
+// Set, Add and RevokeOrganizationMemberProducts read the role and write
+// it back with changed products, the write fails if the role has been
+// written since it was read, e.g. by other instance, and it's retried.
+func (c *Client) SetOrganizationMemberProducts(
+	ctx context.Context,
+	organizationId string,
+	memberId string,
+	products ...Product,
+) (*pb.ZedToken, error) {
+	return c.updateOrganizationMemberProducts(ctx, organizationId, memberId, func([]Product) []Product {
+		return uniqueProducts(products)
+	})
+}
+
+// AddOrganizationMemberProducts enables products for the member
+// of the organization in addition to already enabled ones.
+func (c *Client) AddOrganizationMemberProducts(
+	ctx context.Context,
+	organizationId string,
+	memberId string,
+	products ...Product,
+) (*pb.ZedToken, error) {
+	return c.updateOrganizationMemberProducts(ctx, organizationId, memberId, func(enabled []Product) []Product {
+		return uniqueProducts(append(enabled, products...))
+	})
+}
+
+// RevokeOrganizationMemberProducts disables products for the member
+// of the organization. Products which are not enabled are ignored.
+func (c *Client) RevokeOrganizationMemberProducts(
+	ctx context.Context,
+	organizationId string,
+	memberId string,
+	products ...Product,
+) (*pb.ZedToken, error) {
+	return c.updateOrganizationMemberProducts(ctx, organizationId, memberId, func(enabled []Product) []Product {
+		var kept []Product
+		for _, p := range enabled {
+			if !slices.Contains(products, p) {
+				kept = append(kept, p)
+			}
+		}
+		return kept
+	})
+}
+
+// updateOrganizationMemberProducts writes the member role with products
+// returned by update from the enabled ones.
+func (c *Client) updateOrganizationMemberProducts(
+	ctx context.Context,
+	organizationId string,
+	memberId string,
+	update func(enabled []Product) []Product,
+) (*pb.ZedToken, error) {
+	return c.writeOrganizationRoleRevision(ctx, organizationId, memberId, func(tx *Tx) error {
+		role, err := c.readOrganizationRole(ctx, organizationId, memberId, FullyConsistent())
+		if err != nil {
+			return err
+		}
+
+		rel := &pb.Relationship{
+			Resource: role.Resource,
+			Relation: role.Relation,
+			Subject:  role.Subject,
+			OptionalCaveat: &pb.ContextualizedCaveat{
+				CaveatName: caveatProducts,
+				Context:    newCaveatProducts(productsToStrings(update(enabledProducts(role)))),
+			},
+		}
+		tx.Touch(rel).MustExist(ExactFilter(role))
+		return nil
+	})
+}
+
+// maxRoleWriteAttempts limits attempts to write the member role
+// which keep failing on concurrent writes of the role.
+const maxRoleWriteAttempts = 10
+
+// writeOrganizationRoleRevision commits the transaction built by write
+// together with the next revision of the member role. The commit is
+// conditional on the revision read before write is called, so whatever
+// write reads is still current when it's written. The transaction
+// is built again and retried when the role has been written concurrently,
+// e.g. by other instance, so no change of the role is lost.
+func (c *Client) writeOrganizationRoleRevision(
+	ctx context.Context,
+	organizationId string,
+	memberId string,
+	write func(tx *Tx) error,
+) (*pb.ZedToken, error) {
+	for attempt := 1; ; attempt++ {
+		revision, err := c.readOrganizationRoleRevision(ctx, organizationId, memberId)
+		if err != nil {
+			return nil, err
+		}
+
+		tx := c.Tx()
+		if err := write(tx); err != nil {
+			return nil, err
+		}
+
+		next := 1
+		if revision != nil {
+			n, err := strconv.Atoi(revision.Subject.Object.ObjectId)
+			if err != nil {
+				return nil, fmt.Errorf("authz: invalid role revision %q: %w", relstr(revision), err)
+			}
+			next = n + 1
+
+			tx.Delete(revision).MustExist(ExactFilter(revision))
+		} else {
+			tx.MustNotExist(organizationRoleRevisionFilter(organizationId, memberId))
+		}
+		tx.Touch(organizationRoleRevision(organizationId, memberId, next))
+
+		token, err := tx.Commit(ctx)
+		if errors.Is(err, ErrPreconditionFailed) && attempt < maxRoleWriteAttempts {
+			continue
+		}
+		return token, err
+	}
+}
+
+// readOrganizationRole reads the member role (admin or sdr) relationship.
+func (c *Client) readOrganizationRole(
+	ctx context.Context,
+	organizationId string,
+	memberId string,
+	opts ...ReadOption,
+) (*pb.Relationship, error) {
+	rels, err := c.ReadRelationships(ctx, organizationRoleFilter(organizationId, memberId, ""), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rel := range rels {
+		if rel.Relation == relationAdmin || rel.Relation == relationSDR {
+			return rel, nil
+		}
+	}
+	return nil, fmt.Errorf("authz: member %q does not belong to organization %q", memberId, organizationId)
+}
+
+// readOrganizationRoleRevision reads the revision of the member role,
+// nil if the role has never been written with a revision.
+func (c *Client) readOrganizationRoleRevision(
+	ctx context.Context,
+	organizationId string,
+	memberId string,
+) (*pb.Relationship, error) {
+	rels, err := c.ReadRelationships(ctx, organizationRoleRevisionFilter(organizationId, memberId), FullyConsistent())
+	if err != nil || len(rels) == 0 {
+		return nil, err
+	}
+	return rels[0], nil
+}
+
+func organizationRoleRevision(organizationId, memberId string, revision int) *pb.Relationship {
+	return &pb.Relationship{
+		Resource: &pb.ObjectReference{
+			ObjectType: definitionOrganizationRole,
+			ObjectId:   organizationId + "/" + memberId,
+		},
+		Relation: relationRevision,
+		Subject: &pb.SubjectReference{
+			Object: &pb.ObjectReference{
+				ObjectType: definitionOrganizationRevision,
+				ObjectId:   strconv.Itoa(revision),
+			},
+		},
+	}
+}
+
+func organizationRoleRevisionFilter(organizationId, memberId string) *pb.RelationshipFilter {
+	return &pb.RelationshipFilter{
+		ResourceType:       definitionOrganizationRole,
+		OptionalResourceId: organizationId + "/" + memberId,
+		OptionalRelation:   relationRevision,
+	}
+}
+
+// organizationRoleFilter builds filter matching organization relationships
+// of the member. If relation is empty, all relations are matched.
+func organizationRoleFilter(organizationId, memberId, relation string) *pb.RelationshipFilter {
+	return &pb.RelationshipFilter{
+		ResourceType:       definitionOrganization,
+		OptionalResourceId: organizationId,
+		OptionalRelation:   relation,
+		OptionalSubjectFilter: &pb.SubjectFilter{
+			SubjectType:       definitionMember,
+			OptionalSubjectId: memberId,
+		},
+	}
+}
+
+// enabledProducts returns products stored in the products caveat context.
+func enabledProducts(rel *pb.Relationship) []Product {
+	enabled := rel.GetOptionalCaveat().GetContext().GetFields()[caveatProductsEnabledArg]
+
+	var products []Product
+	for _, v := range enabled.GetListValue().GetValues() {
+		products = append(products, Product(v.GetStringValue()))
+	}
+	return products
+}
+
+func uniqueProducts(products []Product) []Product {
+	var unique []Product
+	for _, p := range products {
+		if !slices.Contains(unique, p) {
+			unique = append(unique, p)
+		}
+	}
+	return unique
+}
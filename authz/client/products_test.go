@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"rift/assert"
+)
+
+func TestProducts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	memberId := "alice"
+
+	t.Run("not_member", func(t *testing.T) {
+		_, err := tclient.GetOrganizationMemberProducts(ctx, orgId, memberId)
+		assert.ErrorContains(t, err, "does not belong to organization")
+
+		_, err = tclient.SetOrganizationMemberProducts(ctx, orgId, memberId, ProductSequences)
+		assert.ErrorContains(t, err, "does not belong to organization")
+	})
+
+	t.Run("write_role", func(t *testing.T) {
+		_, err := tclient.WriteOrganizationSDR(ctx, orgId, memberId, ProductMeetings)
+		assert.NoError(t, err)
+
+		products, err := tclient.GetOrganizationMemberProducts(ctx, orgId, memberId)
+		assert.NoError(t, err)
+		assert.Equal(t, products, []Product{ProductMeetings})
+
+		err = tclient.CanCreateOrganizationMeeting(ctx, orgId, MemberPrincipal(memberId))
+		assert.NoError(t, err)
+
+		err = tclient.CanCreateOrganizationSequence(ctx, orgId, MemberPrincipal(memberId))
+		assert.ErrorContains(t, err, &ErrDenied{})
+	})
+
+	t.Run("add", func(t *testing.T) {
+		_, err := tclient.AddOrganizationMemberProducts(ctx, orgId, memberId, ProductSequences, ProductMeetings)
+		assert.NoError(t, err)
+
+		products, err := tclient.GetOrganizationMemberProducts(ctx, orgId, memberId)
+		assert.NoError(t, err)
+		assert.Equal(t, products, []Product{ProductMeetings, ProductSequences})
+
+		err = tclient.CanCreateOrganizationSequence(ctx, orgId, MemberPrincipal(memberId))
+		assert.NoError(t, err)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		_, err := tclient.RevokeOrganizationMemberProducts(ctx, orgId, memberId, ProductMeetings, ProductWarmer)
+		assert.NoError(t, err)
+
+		products, err := tclient.GetOrganizationMemberProducts(ctx, orgId, memberId)
+		assert.NoError(t, err)
+		assert.Equal(t, products, []Product{ProductSequences})
+
+		err = tclient.CanCreateOrganizationMeeting(ctx, orgId, MemberPrincipal(memberId))
+		assert.ErrorContains(t, err, &ErrDenied{})
+	})
+
+	t.Run("set", func(t *testing.T) {
+		_, err := tclient.SetOrganizationMemberProducts(ctx, orgId, memberId, ProductWarmer, ProductWarmer)
+		assert.NoError(t, err)
+
+		products, err := tclient.GetOrganizationMemberProducts(ctx, orgId, memberId)
+		assert.NoError(t, err)
+		assert.Equal(t, products, []Product{ProductWarmer})
+
+		// role is kept when products change
+		err = tclient.CanEditOrganizationSettings(ctx, orgId, MemberPrincipal(memberId))
+		assert.ErrorContains(t, err, &ErrDenied{})
+	})
+
+	t.Run("role_swap", func(t *testing.T) {
+		_, err := tclient.WriteOrganizationAdmin(ctx, orgId, memberId, ProductWarmer)
+		assert.NoError(t, err)
+
+		rels, err := tclient.ReadRelationships(ctx, organizationRoleFilter(orgId, memberId, ""))
+		assert.NoError(t, err)
+		assert.Len(t, rels, 1)
+		assert.Equal(t, rels[0].Relation, relationAdmin)
+
+		err = tclient.CanCreateOrganizationInbox(ctx, orgId, MemberPrincipal(memberId))
+		assert.NoError(t, err)
+	})
+
+	t.Run("set_empty", func(t *testing.T) {
+		_, err := tclient.SetOrganizationMemberProducts(ctx, orgId, memberId)
+		assert.NoError(t, err)
+
+		products, err := tclient.GetOrganizationMemberProducts(ctx, orgId, memberId)
+		assert.NoError(t, err)
+		assert.Len(t, products, 0)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		// concurrent changes of the member don't overwrite each other
+		all := []Product{ProductSequences, ProductMeetings, ProductWarmer, ProductCalls}
+
+		var wg sync.WaitGroup
+		for _, p := range all {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := tclient.AddOrganizationMemberProducts(ctx, orgId, memberId, p)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		products, err := tclient.GetOrganizationMemberProducts(ctx, orgId, memberId)
+		assert.NoError(t, err)
+		slices.Sort(products)
+		slices.Sort(all)
+		assert.Equal(t, products, all)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := tclient.SetOrganizationMemberProducts(ctx, orgId, memberId, ProductSequences)
+		assert.NoError(t, err)
+
+		// the role is written by other instance after it's read,
+		// so the first write fails and the update is retried
+		calls := 0
+		_, err = tclient.updateOrganizationMemberProducts(ctx, orgId, memberId, func(enabled []Product) []Product {
+			calls++
+			if calls == 1 {
+				_, err := tclient.AddOrganizationMemberProducts(ctx, orgId, memberId, ProductCalls)
+				assert.NoError(t, err)
+			}
+			return append(enabled, ProductMeetings)
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, calls, 2)
+
+		products, err := tclient.GetOrganizationMemberProducts(ctx, orgId, memberId)
+		assert.NoError(t, err)
+		assert.Equal(t, products, []Product{ProductSequences, ProductCalls, ProductMeetings})
+	})
+}
@@ -0,0 +1,389 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Resource implements relationship writes, permission checks
+// and lookups shared by all definitions in the schema.
+// The per definition methods are thin wrappers around it.
+//
+// T is a capability struct which reports permissions a subject
+// has on a single resource, e.g. OffDay.
+type Resource[T any] struct {
+	definition string
+
+	// permissions reported by the capability struct.
+	// The first permission is used to look up resources,
+	// so it must be implied by all other permissions (usually view).
+	permissions []string
+
+	// capability builds the capability struct of the resource
+	// from the permissions granted to the subject.
+	// It is nil for definitions without capability struct.
+	capability func(id string, granted map[string]bool) *T
+}
+
+func newResource[T any](
+	definition string,
+	permissions []string,
+	capability func(id string, granted map[string]bool) *T,
+) *Resource[T] {
+	return &Resource[T]{
+		definition:  definition,
+		permissions: permissions,
+		capability:  capability,
+	}
+}
+
+// Relation builds the relationship between the resource and the subject.
+func (r *Resource[T]) Relation(
+	id string,
+	relation string,
+	subject *pb.SubjectReference,
+) *pb.Relationship {
+	return &pb.Relationship{
+		Resource: objRef(r.definition, id),
+		Relation: relation,
+		Subject:  subject,
+	}
+}
+
+// Filter matches relationships of the resource with subjects of the type.
+// An empty id matches all resources of the definition.
+func (r *Resource[T]) Filter(
+	id string,
+	relation string,
+	subjectType string,
+) *pb.RelationshipFilter {
+	return &pb.RelationshipFilter{
+		ResourceType:          r.definition,
+		OptionalResourceId:    id,
+		OptionalRelation:      relation,
+		OptionalSubjectFilter: &pb.SubjectFilter{SubjectType: subjectType},
+	}
+}
+
+// Write writes the relationship between the resource and the subject.
+func (r *Resource[T]) Write(
+	ctx context.Context,
+	c *Client,
+	id string,
+	relation string,
+	subject *pb.SubjectReference,
+) (*pb.ZedToken, error) {
+	return c.writeRelationship(ctx, r.Relation(id, relation, subject))
+}
+
+// Delete deletes the relationship between the resource and the subject.
+func (r *Resource[T]) Delete(
+	ctx context.Context,
+	c *Client,
+	id string,
+	relation string,
+	subject *pb.SubjectReference,
+) (*pb.ZedToken, error) {
+	return c.deleteRelationship(ctx, r.Relation(id, relation, subject))
+}
+
+// Can checks if the subject has the permission on the resource.
+// Products required by the permission are passed in the caveat context.
+func (r *Resource[T]) Can(
+	ctx context.Context,
+	c *Client,
+	id string,
+	permission string,
+	subject *pb.SubjectReference,
+	opts ...ReadOption,
+) error {
+	req, err := r.checkRequest(ctx, c, id, permission, subject, opts...)
+	if err != nil {
+		return err
+	}
+	return c.checkPermission(ctx, req)
+}
+
+// Explain checks the permission like Can and returns the trace
+// of the check, which explains why the permission was granted or denied.
+// The trace is returned with ErrDenied too. It's nil if the server
+// doesn't return traces.
+func (r *Resource[T]) Explain(
+	ctx context.Context,
+	c *Client,
+	id string,
+	permission string,
+	subject *pb.SubjectReference,
+	opts ...ReadOption,
+) (*Trace, error) {
+	req, err := r.checkRequest(ctx, c, id, permission, subject, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.tracePermission(ctx, req, true)
+}
+
+func (r *Resource[T]) checkRequest(
+	ctx context.Context,
+	c *Client,
+	id string,
+	permission string,
+	subject *pb.SubjectReference,
+	opts ...ReadOption,
+) (*pb.CheckPermissionRequest, error) {
+	caveatContext, err := r.caveatContext(permission, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CheckPermissionRequest{
+		Resource:    objRef(r.definition, id),
+		Permission:  permission,
+		Subject:     subject,
+		Consistency: c.consistency(ctx, opts...),
+		Context:     caveatContext,
+	}, nil
+}
+
+// LookupSubjects streams ids of subjects of the given type which have
+// the permission or relation on the resource. fn is called for every subject,
+// an error returned by fn stops the lookup and is returned as is.
+func (r *Resource[T]) LookupSubjects(
+	ctx context.Context,
+	c *Client,
+	id string,
+	permission string,
+	subjectType string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	caveatContext, err := r.caveatContext(permission, opts...)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.LookupSubjectsRequest{
+		Resource:          objRef(r.definition, id),
+		Permission:        permission,
+		SubjectObjectType: subjectType,
+		Context:           caveatContext,
+		Consistency:       c.consistency(ctx, opts...),
+	}
+	return c.lookupSubjects(ctx, req, fn)
+}
+
+// caveatContext builds the caveat context of the permission check,
+// i.e. products required by the permission merged with the context
+// passed with WithCaveatContext.
+func (r *Resource[T]) caveatContext(permission string, opts ...ReadOption) (*structpb.Struct, error) {
+	caveatContext := newCaveatProductsRequired(r.definition, permission)
+
+	o := newReadOptions(opts...)
+	if len(o.caveatContext) == 0 {
+		return caveatContext, nil
+	}
+
+	extra, err := structpb.NewStruct(o.caveatContext)
+	if err != nil {
+		return nil, fmt.Errorf("authz: caveat context: %w", err)
+	}
+	for k, v := range extra.Fields {
+		caveatContext.Fields[k] = v
+	}
+	return caveatContext, nil
+}
+
+// List returns ids of resources the subject has the permission on.
+func (r *Resource[T]) List(
+	ctx context.Context,
+	c *Client,
+	permission string,
+	subject *pb.SubjectReference,
+	opts ...ReadOption,
+) ([]string, error) {
+	req, err := r.lookupRequest(permission, subject, c.consistency(ctx, opts...), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.lookupResources(ctx, req)
+}
+
+// Iterate iterates over ids of resources the subject has the permission on.
+// See Iterator for paging and resuming the iteration.
+func (r *Resource[T]) Iterate(
+	ctx context.Context,
+	c *Client,
+	permission string,
+	subject *pb.SubjectReference,
+	opts ...ReadOption,
+) *Iterator[string] {
+	req, err := r.lookupRequest(permission, subject, c.consistency(ctx, opts...), opts...)
+	if err != nil {
+		return newErrIterator[string](err)
+	}
+	return c.iterateResources(ctx, req, newReadOptions(opts...))
+}
+
+func (r *Resource[T]) lookupRequest(
+	permission string,
+	subject *pb.SubjectReference,
+	consistency *pb.Consistency,
+	opts ...ReadOption,
+) (*pb.LookupResourcesRequest, error) {
+	caveatContext, err := r.caveatContext(permission, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LookupResourcesRequest{
+		ResourceObjectType: r.definition,
+		Permission:         permission,
+		Subject:            subject,
+		Context:            caveatContext,
+		Consistency:        consistency,
+	}, nil
+}
+
+// ListWithCapabilities returns capabilities of all resources
+// the subject has the first permission on, keyed by resource id.
+// It returns nil if there are no such resources.
+func (r *Resource[T]) ListWithCapabilities(
+	ctx context.Context,
+	c *Client,
+	subject *pb.SubjectReference,
+	opts ...ReadOption,
+) (map[string]*T, error) {
+	// both requests read at the same consistency
+	consistency := c.consistency(ctx, opts...)
+	req, err := r.lookupRequest(r.permissions[0], subject, consistency, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := c.lookupResources(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return r.capabilities(ctx, c, ids, subject, consistency, opts...)
+}
+
+// Get returns capabilities of the resource, it checks all permissions
+// in a single request. It returns ErrDenied if the subject doesn't have
+// the first permission.
+func (r *Resource[T]) Get(
+	ctx context.Context,
+	c *Client,
+	id string,
+	subject *pb.SubjectReference,
+	opts ...ReadOption,
+) (*T, error) {
+	granted, err := r.bulkCheck(ctx, c, []string{id}, r.permissions, subject, c.consistency(ctx, opts...), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !granted[id][r.permissions[0]] {
+		return nil, newErrDenied(objRef(r.definition, id), r.permissions[0], subject)
+	}
+	return r.capability(id, granted[id]), nil
+}
+
+// capabilities checks all permissions but the first one
+// for the given resources in a single request.
+// The first permission is assumed to be granted.
+func (r *Resource[T]) capabilities(
+	ctx context.Context,
+	c *Client,
+	ids []string,
+	subject *pb.SubjectReference,
+	consistency *pb.Consistency,
+	opts ...ReadOption,
+) (map[string]*T, error) {
+	granted, err := r.bulkCheck(ctx, c, ids, r.permissions[1:], subject, consistency, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]*T, len(ids))
+	for id, permissions := range granted {
+		permissions[r.permissions[0]] = true
+		out[id] = r.capability(id, permissions)
+	}
+	return out, nil
+}
+
+// bulkCheck checks the permissions of all given resources in a single request.
+// It returns granted permissions keyed by resource id, there's an entry
+// for every resource.
+func (r *Resource[T]) bulkCheck(
+	ctx context.Context,
+	c *Client,
+	ids []string,
+	permissions []string,
+	subject *pb.SubjectReference,
+	consistency *pb.Consistency,
+	opts ...ReadOption,
+) (map[string]map[string]bool, error) {
+	caveatContexts := make(map[string]*structpb.Struct, len(permissions))
+	for _, permission := range permissions {
+		caveatContext, err := r.caveatContext(permission, opts...)
+		if err != nil {
+			return nil, err
+		}
+		caveatContexts[permission] = caveatContext
+	}
+
+	granted := make(map[string]map[string]bool, len(ids))
+	items := make([]*pb.BulkCheckPermissionRequestItem, 0, len(permissions)*len(ids))
+	for _, id := range ids {
+		granted[id] = make(map[string]bool, len(r.permissions))
+
+		for _, permission := range permissions {
+			items = append(items, &pb.BulkCheckPermissionRequestItem{
+				Resource:   objRef(r.definition, id),
+				Permission: permission,
+				Subject:    subject,
+				Context:    caveatContexts[permission],
+			})
+		}
+	}
+
+	if len(items) == 0 {
+		return granted, nil
+	}
+
+	req := &pb.BulkCheckPermissionRequest{
+		Consistency: consistency,
+		Items:       items,
+	}
+
+	var resp *pb.BulkCheckPermissionResponse
+	err := c.retry(ctx, func() (err error) {
+		resp, err = c.c.BulkCheckPermission(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pair := range resp.GetPairs() {
+		switch res := pair.GetResponse().(type) {
+		case *pb.BulkCheckPermissionPair_Item:
+			if res.Item.Permissionship == pb.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION {
+				granted[pair.Request.Resource.ObjectId][pair.Request.Permission] = true
+			}
+		case *pb.BulkCheckPermissionPair_Error:
+			return nil, fmt.Errorf("%d %s", res.Error.Code, res.Error.Message)
+		default:
+			return nil, fmt.Errorf("unexpected response type %T", res)
+		}
+	}
+	return granted, nil
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy configures retries of idempotent requests
+// failed with transient errors, e.g. during spicedb rollouts.
+//
+// Retried are relationship touches and deletes, permission checks
+// and lookups. Lookup streams are retried only if no result
+// has been received yet.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	// Zero disables retries.
+	MaxRetries uint64
+
+	// InitialInterval is the wait before the first retry,
+	// each next wait is Multiplier times longer up to MaxInterval.
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+
+	// RandomizationFactor is the jitter of the waits,
+	// e.g. 0.5 randomizes the wait in range [0.5 * wait, 1.5 * wait].
+	RandomizationFactor float64
+
+	// Codes are grpc status codes of transient errors.
+	Codes []codes.Code
+}
+
+// DefaultRetryPolicy returns the policy used by clients
+// created without the WithRetry option.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:          3,
+		InitialInterval:     50 * time.Millisecond,
+		MaxInterval:         time.Second,
+		Multiplier:          2,
+		RandomizationFactor: 0.5,
+		Codes: []codes.Code{
+			codes.Unavailable,
+			codes.ResourceExhausted,
+			codes.Aborted,
+		},
+	}
+}
+
+// retryable reports if the error is transient.
+func (p RetryPolicy) retryable(err error) bool {
+	return slices.Contains(p.Codes, status.Code(err))
+}
+
+func (p RetryPolicy) backOff(ctx context.Context) backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = p.InitialInterval
+	b.MaxInterval = p.MaxInterval
+	b.Multiplier = p.Multiplier
+	b.RandomizationFactor = p.RandomizationFactor
+	// number of retries is limited instead of time
+	b.MaxElapsedTime = 0
+	return backoff.WithContext(backoff.WithMaxRetries(b, p.MaxRetries), ctx)
+}
+
+// retry calls the idempotent operation until it succeeds,
+// fails with non transient error or retries are exhausted.
+// The operation may mark an error as final with backoff.Permanent.
+func (c *Client) retry(ctx context.Context, op func() error) error {
+	if c.retryPolicy.MaxRetries == 0 {
+		return op()
+	}
+
+	return backoff.Retry(func() error {
+		err := op()
+		if err != nil && !c.retryPolicy.retryable(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, c.retryPolicy.backOff(ctx))
+}
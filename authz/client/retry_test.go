@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	policy := DefaultRetryPolicy()
+	policy.InitialInterval = time.Millisecond
+	policy.MaxInterval = time.Millisecond
+
+	const (
+		writeMethod  = "/authzed.api.v1.PermissionsService/WriteRelationships"
+		lookupMethod = "/authzed.api.v1.PermissionsService/LookupResources"
+		readMethod   = "/authzed.api.v1.PermissionsService/ReadRelationships"
+	)
+
+	t.Run("write", func(t *testing.T) {
+		f := &failer{method: writeMethod, code: codes.Unavailable, failures: 2}
+		tclient, err := StartTestServer(ctx, WithRetry(policy), f.options())
+		assert.NoError(t, err)
+
+		_, err = tclient.WriteOffDayOrganization(ctx, "offday", "org")
+		assert.NoError(t, err)
+		assert.Equal(t, f.attempts, 3)
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		f := &failer{method: writeMethod, code: codes.ResourceExhausted, failures: 10}
+		tclient, err := StartTestServer(ctx, WithRetry(policy), f.options())
+		assert.NoError(t, err)
+
+		_, err = tclient.DeleteOffDayOrganization(ctx, "offday", "org")
+		assert.Equal(t, status.Code(err), codes.ResourceExhausted)
+		assert.Equal(t, f.attempts, int(policy.MaxRetries)+1)
+	})
+
+	t.Run("non_transient", func(t *testing.T) {
+		f := &failer{method: writeMethod, code: codes.InvalidArgument, failures: 10}
+		tclient, err := StartTestServer(ctx, WithRetry(policy), f.options())
+		assert.NoError(t, err)
+
+		_, err = tclient.WriteOffDayOrganization(ctx, "offday", "org")
+		assert.Equal(t, status.Code(err), codes.InvalidArgument)
+		assert.Equal(t, f.attempts, 1)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		f := &failer{method: writeMethod, code: codes.Unavailable, failures: 10}
+		tclient, err := StartTestServer(ctx, WithRetry(RetryPolicy{}), f.options())
+		assert.NoError(t, err)
+
+		_, err = tclient.WriteOffDayOrganization(ctx, "offday", "org")
+		assert.Equal(t, status.Code(err), codes.Unavailable)
+		assert.Equal(t, f.attempts, 1)
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		f := &failer{method: lookupMethod, code: codes.Aborted, failures: 1}
+		tclient, err := StartTestServer(ctx, WithRetry(policy), f.options())
+		assert.NoError(t, err)
+
+		_, err = tclient.WriteOrganizationAdmin(ctx, "org", "alice")
+		assert.NoError(t, err)
+		_, err = tclient.WriteOffDayOrganization(ctx, "offday", "org")
+		assert.NoError(t, err)
+
+		ids, err := tclient.ListViewOffDays(ctx, MemberPrincipal("alice"))
+		assert.NoError(t, err)
+		assert.Equal(t, ids, []string{"offday"})
+		assert.Equal(t, f.attempts, 2)
+	})
+
+	t.Run("lookup_partial", func(t *testing.T) {
+		f := &failer{method: lookupMethod, code: codes.Unavailable, failures: 1, afterFirst: true}
+		tclient, err := StartTestServer(ctx, WithRetry(policy), f.options())
+		assert.NoError(t, err)
+
+		_, err = tclient.WriteOrganizationAdmin(ctx, "org", "alice")
+		assert.NoError(t, err)
+		_, err = tclient.WriteOffDayOrganization(ctx, "offday", "org")
+		assert.NoError(t, err)
+
+		_, err = tclient.ListViewOffDays(ctx, MemberPrincipal("alice"))
+		assert.Equal(t, status.Code(err), codes.Unavailable)
+		assert.Equal(t, f.attempts, 1)
+	})
+
+	t.Run("has_relationships", func(t *testing.T) {
+		f := &failer{method: readMethod, code: codes.Unavailable, failures: 1}
+		tclient, err := StartTestServer(ctx, WithRetry(policy), f.options())
+		assert.NoError(t, err)
+
+		_, err = tclient.WriteOffDayOrganization(ctx, "offday", "org")
+		assert.NoError(t, err)
+
+		found, err := tclient.HasRelationships(ctx, FilterOffDayOrganization("offday"))
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, f.attempts, 2)
+
+		// errors received from the stream are wrapped too
+		_, err = tclient.HasRelationships(ctx, &pb.RelationshipFilter{ResourceType: "unknown"})
+		assert.Equal(t, status.Code(err), codes.FailedPrecondition)
+		assert.ErrorContains(t, err, "authz: read relationships")
+	})
+}
+
+// failer fails the first calls of the method with the code.
+type failer struct {
+	method   string
+	code     codes.Code
+	failures int
+
+	// afterFirst fails streams after the first message is received.
+	afterFirst bool
+
+	attempts int
+}
+
+func (f *failer) fail(method string) bool {
+	if method != f.method {
+		return false
+	}
+	f.attempts++
+	return f.attempts <= f.failures
+}
+
+func (f *failer) options() Option {
+	return WithDialOptions(
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if f.fail(method) {
+				return status.Error(f.code, "failer")
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			if !f.fail(method) {
+				return streamer(ctx, desc, cc, method, opts...)
+			}
+			if !f.afterFirst {
+				return nil, status.Error(f.code, "failer")
+			}
+
+			stream, err := streamer(ctx, desc, cc, method, opts...)
+			if err != nil {
+				return nil, err
+			}
+			return &failingStream{ClientStream: stream, code: f.code}, nil
+		}),
+	)
+}
+
+// failingStream fails after the first received message.
+type failingStream struct {
+	grpc.ClientStream
+	code     codes.Code
+	received bool
+}
+
+func (s *failingStream) RecvMsg(m any) error {
+	if s.received {
+		return status.Error(s.code, "failer")
+	}
+	s.received = true
+	return s.ClientStream.RecvMsg(m)
+}
@@ -0,0 +1,3643 @@
+// Code generated by authzgen from schemas/v2.zed. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+const (
+	caveatProducts            = "products"
+	caveatProductsEnabledArg  = "enabled"  // list<string>
+	caveatProductsOneOfArg    = "oneOf"    // bool
+	caveatProductsRequiredArg = "required" // list<string>
+
+	caveatChameleonEmail    = "chameleon_email"
+	caveatChameleonEmailArg = "email" // string
+)
+
+const (
+	definitionUser                 = "user"
+	definitionPlatform             = "platform"
+	definitionApiKey               = "apikey"
+	definitionMember               = "member"
+	definitionOrganization         = "organization"
+	definitionOrganizationRole     = "organization/role"
+	definitionOrganizationRevision = "organization/revision"
+	definitionTeam                 = "team"
+	definitionOffDay               = "offday"
+	definitionHoliday              = "holiday"
+	definitionPassword             = "password"
+	definitionContact              = "contact"
+	definitionInbox                = "inbox"
+	definitionSequence             = "sequence"
+	definitionSequenceAction       = "sequence/action"
+	definitionMeeting              = "meeting"
+)
+
+const (
+	relationAdmin        = "admin"
+	relationApiKey       = "apikey"
+	relationAssignee     = "assignee"
+	relationChameleoner  = "chameleoner"
+	relationContact      = "contact"
+	relationEditor       = "editor"
+	relationOrganization = "organization"
+	relationOwner        = "owner"
+	relationRevision     = "revision"
+	relationSDR          = "sdr"
+	relationSender       = "sender"
+	relationSequence     = "sequence"
+	relationViewer       = "viewer"
+)
+
+const (
+	permissionAccess             = "access"
+	permissionChameleon          = "chameleon"
+	permissionCreateCallStep     = "create_call_step"
+	permissionCreateHoliday      = "create_holiday"
+	permissionCreateInbox        = "create_inbox"
+	permissionCreateMeeting      = "create_meeting"
+	permissionCreateOffDay       = "create_offday"
+	permissionCreatePassword     = "create_password"
+	permissionCreateSequence     = "create_sequence"
+	permissionCreateTeam         = "create_team"
+	permissionDelete             = "delete"
+	permissionDeleteMember       = "delete_member"
+	permissionEdit               = "edit"
+	permissionEditMember         = "edit_member"
+	permissionEditSettings       = "edit_settings"
+	permissionInviteMember       = "invite_member"
+	permissionManageSeat         = "manage_seat"
+	permissionOrganizationAccess = "organization_access"
+	permissionOrganizationAdmin  = "organization_admin"
+	permissionOrganizationApiKey = "organization_apikey"
+	permissionUploadContact      = "upload_contact"
+	permissionView               = "view"
+	permissionViewSettings       = "view_settings"
+)
+
+// Organization reports permissions the principal has on the organization.
+type Organization struct {
+	Id             string
+	Access         bool
+	EditSettings   bool
+	ViewSettings   bool
+	InviteMember   bool
+	EditMember     bool
+	DeleteMember   bool
+	CreateTeam     bool
+	CreatePassword bool
+	CreateOffDay   bool
+	CreateHoliday  bool
+	CreateSequence bool
+	CreateInbox    bool
+	CreateMeeting  bool
+	ManageSeat     bool
+}
+
+var organizationResource = newResource(
+	definitionOrganization,
+	[]string{
+		permissionAccess,
+		permissionEditSettings,
+		permissionViewSettings,
+		permissionInviteMember,
+		permissionEditMember,
+		permissionDeleteMember,
+		permissionCreateTeam,
+		permissionCreatePassword,
+		permissionCreateOffDay,
+		permissionCreateHoliday,
+		permissionCreateSequence,
+		permissionCreateInbox,
+		permissionCreateMeeting,
+		permissionManageSeat,
+	},
+	func(id string, granted map[string]bool) *Organization {
+		return &Organization{
+			Id:             id,
+			Access:         granted[permissionAccess],
+			EditSettings:   granted[permissionEditSettings],
+			ViewSettings:   granted[permissionViewSettings],
+			InviteMember:   granted[permissionInviteMember],
+			EditMember:     granted[permissionEditMember],
+			DeleteMember:   granted[permissionDeleteMember],
+			CreateTeam:     granted[permissionCreateTeam],
+			CreatePassword: granted[permissionCreatePassword],
+			CreateOffDay:   granted[permissionCreateOffDay],
+			CreateHoliday:  granted[permissionCreateHoliday],
+			CreateSequence: granted[permissionCreateSequence],
+			CreateInbox:    granted[permissionCreateInbox],
+			CreateMeeting:  granted[permissionCreateMeeting],
+			ManageSeat:     granted[permissionManageSeat],
+		}
+	},
+)
+
+// RelationOrganizationApiKey builds organization#apikey@apikey relationship.
+func RelationOrganizationApiKey(
+	organizationId string,
+	apiKeyId string,
+) *pb.Relationship {
+	return organizationResource.Relation(organizationId, relationApiKey, subRef(definitionApiKey, apiKeyId))
+}
+
+// FilterOrganizationApiKey matches organization#apikey@apikey relationships
+// of the organization, or of any organization if the id is empty.
+func FilterOrganizationApiKey(organizationId string) *pb.RelationshipFilter {
+	return organizationResource.Filter(organizationId, relationApiKey, definitionApiKey)
+}
+
+// WriteOrganizationApiKey writes organization#apikey@apikey relationship.
+func (c *Client) WriteOrganizationApiKey(
+	ctx context.Context,
+	organizationId string,
+	apiKeyId string,
+) (*pb.ZedToken, error) {
+	return organizationResource.Write(ctx, c, organizationId, relationApiKey, subRef(definitionApiKey, apiKeyId))
+}
+
+// DeleteOrganizationApiKey deletes organization#apikey@apikey relationship.
+func (c *Client) DeleteOrganizationApiKey(
+	ctx context.Context,
+	organizationId string,
+	apiKeyId string,
+) (*pb.ZedToken, error) {
+	return organizationResource.Delete(ctx, c, organizationId, relationApiKey, subRef(definitionApiKey, apiKeyId))
+}
+
+// LookupOrganizationApiKeys streams apikey subjects of organization#apikey relation.
+func (c *Client) LookupOrganizationApiKeys(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, relationApiKey, definitionApiKey, fn, opts...)
+}
+
+// RelationOrganizationAdmin builds organization#admin@member relationship.
+func RelationOrganizationAdmin(
+	organizationId string,
+	memberId string,
+	products ...Product,
+) *pb.Relationship {
+	rel := organizationResource.Relation(organizationId, relationAdmin, subRef(definitionMember, memberId))
+	rel.OptionalCaveat = &pb.ContextualizedCaveat{
+		CaveatName: caveatProducts,
+		Context:    newCaveatProducts(productsToStrings(products)),
+	}
+	return rel
+}
+
+// FilterOrganizationAdmin matches organization#admin@member relationships
+// of the organization, or of any organization if the id is empty.
+func FilterOrganizationAdmin(organizationId string) *pb.RelationshipFilter {
+	return organizationResource.Filter(organizationId, relationAdmin, definitionMember)
+}
+
+// DeleteOrganizationAdmin deletes organization#admin@member relationship.
+func (c *Client) DeleteOrganizationAdmin(
+	ctx context.Context,
+	organizationId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return organizationResource.Delete(ctx, c, organizationId, relationAdmin, subRef(definitionMember, memberId))
+}
+
+// LookupOrganizationAdmins streams member subjects of organization#admin relation.
+func (c *Client) LookupOrganizationAdmins(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, relationAdmin, definitionMember, fn, opts...)
+}
+
+// RelationOrganizationSDR builds organization#sdr@member relationship.
+func RelationOrganizationSDR(
+	organizationId string,
+	memberId string,
+	products ...Product,
+) *pb.Relationship {
+	rel := organizationResource.Relation(organizationId, relationSDR, subRef(definitionMember, memberId))
+	rel.OptionalCaveat = &pb.ContextualizedCaveat{
+		CaveatName: caveatProducts,
+		Context:    newCaveatProducts(productsToStrings(products)),
+	}
+	return rel
+}
+
+// FilterOrganizationSDR matches organization#sdr@member relationships
+// of the organization, or of any organization if the id is empty.
+func FilterOrganizationSDR(organizationId string) *pb.RelationshipFilter {
+	return organizationResource.Filter(organizationId, relationSDR, definitionMember)
+}
+
+// DeleteOrganizationSDR deletes organization#sdr@member relationship.
+func (c *Client) DeleteOrganizationSDR(
+	ctx context.Context,
+	organizationId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return organizationResource.Delete(ctx, c, organizationId, relationSDR, subRef(definitionMember, memberId))
+}
+
+// LookupOrganizationSDRs streams member subjects of organization#sdr relation.
+func (c *Client) LookupOrganizationSDRs(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, relationSDR, definitionMember, fn, opts...)
+}
+
+// CanAccessOrganization checks if the principal has organization#access permission.
+func (c *Client) CanAccessOrganization(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionAccess, principal.ref(), opts...)
+}
+
+// ExplainAccessOrganization checks the permission like CanAccessOrganization
+// and returns the trace explaining the result.
+func (c *Client) ExplainAccessOrganization(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionAccess, principal.ref(), opts...)
+}
+
+// LookupAccessOrganizationMembers streams member subjects
+// which have organization#access permission.
+func (c *Client) LookupAccessOrganizationMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionAccess, definitionMember, fn, opts...)
+}
+
+// ListAccessOrganizations returns ids of organization resources
+// the principal has access permission on.
+func (c *Client) ListAccessOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionAccess, principal.ref(), opts...)
+}
+
+// IterateAccessOrganizations iterates over ids of organization resources
+// the principal has access permission on.
+func (c *Client) IterateAccessOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionAccess, principal.ref(), opts...)
+}
+
+// CanEditOrganizationSettings checks if the principal has organization#edit_settings permission.
+func (c *Client) CanEditOrganizationSettings(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionEditSettings, principal.ref(), opts...)
+}
+
+// ExplainEditOrganizationSettings checks the permission like CanEditOrganizationSettings
+// and returns the trace explaining the result.
+func (c *Client) ExplainEditOrganizationSettings(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionEditSettings, principal.ref(), opts...)
+}
+
+// LookupEditOrganizationSettingsMembers streams member subjects
+// which have organization#edit_settings permission.
+func (c *Client) LookupEditOrganizationSettingsMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionEditSettings, definitionMember, fn, opts...)
+}
+
+// ListEditSettingsOrganizations returns ids of organization resources
+// the principal has edit_settings permission on.
+func (c *Client) ListEditSettingsOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionEditSettings, principal.ref(), opts...)
+}
+
+// IterateEditSettingsOrganizations iterates over ids of organization resources
+// the principal has edit_settings permission on.
+func (c *Client) IterateEditSettingsOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionEditSettings, principal.ref(), opts...)
+}
+
+// CanViewOrganizationSettings checks if the principal has organization#view_settings permission.
+func (c *Client) CanViewOrganizationSettings(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionViewSettings, principal.ref(), opts...)
+}
+
+// ExplainViewOrganizationSettings checks the permission like CanViewOrganizationSettings
+// and returns the trace explaining the result.
+func (c *Client) ExplainViewOrganizationSettings(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionViewSettings, principal.ref(), opts...)
+}
+
+// LookupViewOrganizationSettingsMembers streams member subjects
+// which have organization#view_settings permission.
+func (c *Client) LookupViewOrganizationSettingsMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionViewSettings, definitionMember, fn, opts...)
+}
+
+// ListViewSettingsOrganizations returns ids of organization resources
+// the principal has view_settings permission on.
+func (c *Client) ListViewSettingsOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionViewSettings, principal.ref(), opts...)
+}
+
+// IterateViewSettingsOrganizations iterates over ids of organization resources
+// the principal has view_settings permission on.
+func (c *Client) IterateViewSettingsOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionViewSettings, principal.ref(), opts...)
+}
+
+// CanInviteOrganizationMember checks if the principal has organization#invite_member permission.
+func (c *Client) CanInviteOrganizationMember(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionInviteMember, principal.ref(), opts...)
+}
+
+// ExplainInviteOrganizationMember checks the permission like CanInviteOrganizationMember
+// and returns the trace explaining the result.
+func (c *Client) ExplainInviteOrganizationMember(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionInviteMember, principal.ref(), opts...)
+}
+
+// LookupInviteOrganizationMemberMembers streams member subjects
+// which have organization#invite_member permission.
+func (c *Client) LookupInviteOrganizationMemberMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionInviteMember, definitionMember, fn, opts...)
+}
+
+// ListInviteMemberOrganizations returns ids of organization resources
+// the principal has invite_member permission on.
+func (c *Client) ListInviteMemberOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionInviteMember, principal.ref(), opts...)
+}
+
+// IterateInviteMemberOrganizations iterates over ids of organization resources
+// the principal has invite_member permission on.
+func (c *Client) IterateInviteMemberOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionInviteMember, principal.ref(), opts...)
+}
+
+// CanEditOrganizationMember checks if the principal has organization#edit_member permission.
+func (c *Client) CanEditOrganizationMember(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionEditMember, principal.ref(), opts...)
+}
+
+// ExplainEditOrganizationMember checks the permission like CanEditOrganizationMember
+// and returns the trace explaining the result.
+func (c *Client) ExplainEditOrganizationMember(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionEditMember, principal.ref(), opts...)
+}
+
+// LookupEditOrganizationMemberMembers streams member subjects
+// which have organization#edit_member permission.
+func (c *Client) LookupEditOrganizationMemberMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionEditMember, definitionMember, fn, opts...)
+}
+
+// ListEditMemberOrganizations returns ids of organization resources
+// the principal has edit_member permission on.
+func (c *Client) ListEditMemberOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionEditMember, principal.ref(), opts...)
+}
+
+// IterateEditMemberOrganizations iterates over ids of organization resources
+// the principal has edit_member permission on.
+func (c *Client) IterateEditMemberOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionEditMember, principal.ref(), opts...)
+}
+
+// CanDeleteOrganizationMember checks if the principal has organization#delete_member permission.
+func (c *Client) CanDeleteOrganizationMember(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionDeleteMember, principal.ref(), opts...)
+}
+
+// ExplainDeleteOrganizationMember checks the permission like CanDeleteOrganizationMember
+// and returns the trace explaining the result.
+func (c *Client) ExplainDeleteOrganizationMember(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionDeleteMember, principal.ref(), opts...)
+}
+
+// LookupDeleteOrganizationMemberMembers streams member subjects
+// which have organization#delete_member permission.
+func (c *Client) LookupDeleteOrganizationMemberMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionDeleteMember, definitionMember, fn, opts...)
+}
+
+// ListDeleteMemberOrganizations returns ids of organization resources
+// the principal has delete_member permission on.
+func (c *Client) ListDeleteMemberOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionDeleteMember, principal.ref(), opts...)
+}
+
+// IterateDeleteMemberOrganizations iterates over ids of organization resources
+// the principal has delete_member permission on.
+func (c *Client) IterateDeleteMemberOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionDeleteMember, principal.ref(), opts...)
+}
+
+// CanCreateOrganizationTeam checks if the principal has organization#create_team permission.
+func (c *Client) CanCreateOrganizationTeam(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionCreateTeam, principal.ref(), opts...)
+}
+
+// ExplainCreateOrganizationTeam checks the permission like CanCreateOrganizationTeam
+// and returns the trace explaining the result.
+func (c *Client) ExplainCreateOrganizationTeam(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionCreateTeam, principal.ref(), opts...)
+}
+
+// LookupCreateOrganizationTeamMembers streams member subjects
+// which have organization#create_team permission.
+func (c *Client) LookupCreateOrganizationTeamMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionCreateTeam, definitionMember, fn, opts...)
+}
+
+// ListCreateTeamOrganizations returns ids of organization resources
+// the principal has create_team permission on.
+func (c *Client) ListCreateTeamOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionCreateTeam, principal.ref(), opts...)
+}
+
+// IterateCreateTeamOrganizations iterates over ids of organization resources
+// the principal has create_team permission on.
+func (c *Client) IterateCreateTeamOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionCreateTeam, principal.ref(), opts...)
+}
+
+// CanCreateOrganizationPassword checks if the principal has organization#create_password permission.
+func (c *Client) CanCreateOrganizationPassword(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionCreatePassword, principal.ref(), opts...)
+}
+
+// ExplainCreateOrganizationPassword checks the permission like CanCreateOrganizationPassword
+// and returns the trace explaining the result.
+func (c *Client) ExplainCreateOrganizationPassword(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionCreatePassword, principal.ref(), opts...)
+}
+
+// LookupCreateOrganizationPasswordMembers streams member subjects
+// which have organization#create_password permission.
+func (c *Client) LookupCreateOrganizationPasswordMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionCreatePassword, definitionMember, fn, opts...)
+}
+
+// ListCreatePasswordOrganizations returns ids of organization resources
+// the principal has create_password permission on.
+func (c *Client) ListCreatePasswordOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionCreatePassword, principal.ref(), opts...)
+}
+
+// IterateCreatePasswordOrganizations iterates over ids of organization resources
+// the principal has create_password permission on.
+func (c *Client) IterateCreatePasswordOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionCreatePassword, principal.ref(), opts...)
+}
+
+// CanCreateOrganizationOffDay checks if the principal has organization#create_offday permission.
+func (c *Client) CanCreateOrganizationOffDay(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionCreateOffDay, principal.ref(), opts...)
+}
+
+// ExplainCreateOrganizationOffDay checks the permission like CanCreateOrganizationOffDay
+// and returns the trace explaining the result.
+func (c *Client) ExplainCreateOrganizationOffDay(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionCreateOffDay, principal.ref(), opts...)
+}
+
+// LookupCreateOrganizationOffDayMembers streams member subjects
+// which have organization#create_offday permission.
+func (c *Client) LookupCreateOrganizationOffDayMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionCreateOffDay, definitionMember, fn, opts...)
+}
+
+// ListCreateOffDayOrganizations returns ids of organization resources
+// the principal has create_offday permission on.
+func (c *Client) ListCreateOffDayOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionCreateOffDay, principal.ref(), opts...)
+}
+
+// IterateCreateOffDayOrganizations iterates over ids of organization resources
+// the principal has create_offday permission on.
+func (c *Client) IterateCreateOffDayOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionCreateOffDay, principal.ref(), opts...)
+}
+
+// CanCreateOrganizationHoliday checks if the principal has organization#create_holiday permission.
+func (c *Client) CanCreateOrganizationHoliday(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionCreateHoliday, principal.ref(), opts...)
+}
+
+// ExplainCreateOrganizationHoliday checks the permission like CanCreateOrganizationHoliday
+// and returns the trace explaining the result.
+func (c *Client) ExplainCreateOrganizationHoliday(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionCreateHoliday, principal.ref(), opts...)
+}
+
+// LookupCreateOrganizationHolidayMembers streams member subjects
+// which have organization#create_holiday permission.
+func (c *Client) LookupCreateOrganizationHolidayMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionCreateHoliday, definitionMember, fn, opts...)
+}
+
+// ListCreateHolidayOrganizations returns ids of organization resources
+// the principal has create_holiday permission on.
+func (c *Client) ListCreateHolidayOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionCreateHoliday, principal.ref(), opts...)
+}
+
+// IterateCreateHolidayOrganizations iterates over ids of organization resources
+// the principal has create_holiday permission on.
+func (c *Client) IterateCreateHolidayOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionCreateHoliday, principal.ref(), opts...)
+}
+
+// CanCreateOrganizationSequence checks if the principal has organization#create_sequence permission.
+func (c *Client) CanCreateOrganizationSequence(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionCreateSequence, principal.ref(), opts...)
+}
+
+// ExplainCreateOrganizationSequence checks the permission like CanCreateOrganizationSequence
+// and returns the trace explaining the result.
+func (c *Client) ExplainCreateOrganizationSequence(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionCreateSequence, principal.ref(), opts...)
+}
+
+// LookupCreateOrganizationSequenceMembers streams member subjects
+// which have organization#create_sequence permission.
+func (c *Client) LookupCreateOrganizationSequenceMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionCreateSequence, definitionMember, fn, opts...)
+}
+
+// ListCreateSequenceOrganizations returns ids of organization resources
+// the principal has create_sequence permission on.
+func (c *Client) ListCreateSequenceOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionCreateSequence, principal.ref(), opts...)
+}
+
+// IterateCreateSequenceOrganizations iterates over ids of organization resources
+// the principal has create_sequence permission on.
+func (c *Client) IterateCreateSequenceOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionCreateSequence, principal.ref(), opts...)
+}
+
+// CanCreateOrganizationInbox checks if the principal has organization#create_inbox permission.
+func (c *Client) CanCreateOrganizationInbox(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionCreateInbox, principal.ref(), opts...)
+}
+
+// ExplainCreateOrganizationInbox checks the permission like CanCreateOrganizationInbox
+// and returns the trace explaining the result.
+func (c *Client) ExplainCreateOrganizationInbox(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionCreateInbox, principal.ref(), opts...)
+}
+
+// LookupCreateOrganizationInboxMembers streams member subjects
+// which have organization#create_inbox permission.
+func (c *Client) LookupCreateOrganizationInboxMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionCreateInbox, definitionMember, fn, opts...)
+}
+
+// ListCreateInboxOrganizations returns ids of organization resources
+// the principal has create_inbox permission on.
+func (c *Client) ListCreateInboxOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionCreateInbox, principal.ref(), opts...)
+}
+
+// IterateCreateInboxOrganizations iterates over ids of organization resources
+// the principal has create_inbox permission on.
+func (c *Client) IterateCreateInboxOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionCreateInbox, principal.ref(), opts...)
+}
+
+// CanCreateOrganizationMeeting checks if the principal has organization#create_meeting permission.
+func (c *Client) CanCreateOrganizationMeeting(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionCreateMeeting, principal.ref(), opts...)
+}
+
+// ExplainCreateOrganizationMeeting checks the permission like CanCreateOrganizationMeeting
+// and returns the trace explaining the result.
+func (c *Client) ExplainCreateOrganizationMeeting(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionCreateMeeting, principal.ref(), opts...)
+}
+
+// LookupCreateOrganizationMeetingMembers streams member subjects
+// which have organization#create_meeting permission.
+func (c *Client) LookupCreateOrganizationMeetingMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionCreateMeeting, definitionMember, fn, opts...)
+}
+
+// ListCreateMeetingOrganizations returns ids of organization resources
+// the principal has create_meeting permission on.
+func (c *Client) ListCreateMeetingOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionCreateMeeting, principal.ref(), opts...)
+}
+
+// IterateCreateMeetingOrganizations iterates over ids of organization resources
+// the principal has create_meeting permission on.
+func (c *Client) IterateCreateMeetingOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionCreateMeeting, principal.ref(), opts...)
+}
+
+// CanManageOrganizationSeat checks if the principal has organization#manage_seat permission.
+func (c *Client) CanManageOrganizationSeat(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return organizationResource.Can(ctx, c, organizationId, permissionManageSeat, principal.ref(), opts...)
+}
+
+// ExplainManageOrganizationSeat checks the permission like CanManageOrganizationSeat
+// and returns the trace explaining the result.
+func (c *Client) ExplainManageOrganizationSeat(
+	ctx context.Context,
+	organizationId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return organizationResource.Explain(ctx, c, organizationId, permissionManageSeat, principal.ref(), opts...)
+}
+
+// LookupManageOrganizationSeatMembers streams member subjects
+// which have organization#manage_seat permission.
+func (c *Client) LookupManageOrganizationSeatMembers(
+	ctx context.Context,
+	organizationId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return organizationResource.LookupSubjects(ctx, c, organizationId, permissionManageSeat, definitionMember, fn, opts...)
+}
+
+// ListManageSeatOrganizations returns ids of organization resources
+// the principal has manage_seat permission on.
+func (c *Client) ListManageSeatOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return organizationResource.List(ctx, c, permissionManageSeat, principal.ref(), opts...)
+}
+
+// IterateManageSeatOrganizations iterates over ids of organization resources
+// the principal has manage_seat permission on.
+func (c *Client) IterateManageSeatOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return organizationResource.Iterate(ctx, c, permissionManageSeat, principal.ref(), opts...)
+}
+
+// ListOrganizations returns capabilities of organization resources
+// the principal has access to, keyed by resource id.
+func (c *Client) ListOrganizations(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) (map[string]*Organization, error) {
+	return organizationResource.ListWithCapabilities(ctx, c, principal.ref(), opts...)
+}
+
+// Team reports permissions the principal has on the team.
+type Team struct {
+	Id     string
+	View   bool
+	Edit   bool
+	Delete bool
+}
+
+var teamResource = newResource(
+	definitionTeam,
+	[]string{
+		permissionView,
+		permissionEdit,
+		permissionDelete,
+	},
+	func(id string, granted map[string]bool) *Team {
+		return &Team{
+			Id:     id,
+			View:   granted[permissionView],
+			Edit:   granted[permissionEdit],
+			Delete: granted[permissionDelete],
+		}
+	},
+)
+
+// RelationTeamOrganization builds team#organization@organization relationship.
+func RelationTeamOrganization(
+	teamId string,
+	organizationId string,
+) *pb.Relationship {
+	return teamResource.Relation(teamId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// FilterTeamOrganization matches team#organization@organization relationships
+// of the team, or of any team if the id is empty.
+func FilterTeamOrganization(teamId string) *pb.RelationshipFilter {
+	return teamResource.Filter(teamId, relationOrganization, definitionOrganization)
+}
+
+// WriteTeamOrganization writes team#organization@organization relationship.
+func (c *Client) WriteTeamOrganization(
+	ctx context.Context,
+	teamId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return teamResource.Write(ctx, c, teamId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// DeleteTeamOrganization deletes team#organization@organization relationship.
+func (c *Client) DeleteTeamOrganization(
+	ctx context.Context,
+	teamId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return teamResource.Delete(ctx, c, teamId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// CanEditTeam checks if the principal has team#edit permission.
+func (c *Client) CanEditTeam(
+	ctx context.Context,
+	teamId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return teamResource.Can(ctx, c, teamId, permissionEdit, principal.ref(), opts...)
+}
+
+// ExplainEditTeam checks the permission like CanEditTeam
+// and returns the trace explaining the result.
+func (c *Client) ExplainEditTeam(
+	ctx context.Context,
+	teamId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return teamResource.Explain(ctx, c, teamId, permissionEdit, principal.ref(), opts...)
+}
+
+// LookupEditTeamMembers streams member subjects
+// which have team#edit permission.
+func (c *Client) LookupEditTeamMembers(
+	ctx context.Context,
+	teamId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return teamResource.LookupSubjects(ctx, c, teamId, permissionEdit, definitionMember, fn, opts...)
+}
+
+// ListEditTeams returns ids of team resources
+// the principal has edit permission on.
+func (c *Client) ListEditTeams(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return teamResource.List(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// IterateEditTeams iterates over ids of team resources
+// the principal has edit permission on.
+func (c *Client) IterateEditTeams(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return teamResource.Iterate(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// CanViewTeam checks if the principal has team#view permission.
+func (c *Client) CanViewTeam(
+	ctx context.Context,
+	teamId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return teamResource.Can(ctx, c, teamId, permissionView, principal.ref(), opts...)
+}
+
+// ExplainViewTeam checks the permission like CanViewTeam
+// and returns the trace explaining the result.
+func (c *Client) ExplainViewTeam(
+	ctx context.Context,
+	teamId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return teamResource.Explain(ctx, c, teamId, permissionView, principal.ref(), opts...)
+}
+
+// LookupViewTeamMembers streams member subjects
+// which have team#view permission.
+func (c *Client) LookupViewTeamMembers(
+	ctx context.Context,
+	teamId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return teamResource.LookupSubjects(ctx, c, teamId, permissionView, definitionMember, fn, opts...)
+}
+
+// ListViewTeams returns ids of team resources
+// the principal has view permission on.
+func (c *Client) ListViewTeams(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return teamResource.List(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// IterateViewTeams iterates over ids of team resources
+// the principal has view permission on.
+func (c *Client) IterateViewTeams(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return teamResource.Iterate(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// CanDeleteTeam checks if the principal has team#delete permission.
+func (c *Client) CanDeleteTeam(
+	ctx context.Context,
+	teamId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return teamResource.Can(ctx, c, teamId, permissionDelete, principal.ref(), opts...)
+}
+
+// ExplainDeleteTeam checks the permission like CanDeleteTeam
+// and returns the trace explaining the result.
+func (c *Client) ExplainDeleteTeam(
+	ctx context.Context,
+	teamId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return teamResource.Explain(ctx, c, teamId, permissionDelete, principal.ref(), opts...)
+}
+
+// LookupDeleteTeamMembers streams member subjects
+// which have team#delete permission.
+func (c *Client) LookupDeleteTeamMembers(
+	ctx context.Context,
+	teamId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return teamResource.LookupSubjects(ctx, c, teamId, permissionDelete, definitionMember, fn, opts...)
+}
+
+// ListDeleteTeams returns ids of team resources
+// the principal has delete permission on.
+func (c *Client) ListDeleteTeams(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return teamResource.List(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// IterateDeleteTeams iterates over ids of team resources
+// the principal has delete permission on.
+func (c *Client) IterateDeleteTeams(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return teamResource.Iterate(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// ListTeams returns capabilities of team resources
+// the principal has access to, keyed by resource id.
+func (c *Client) ListTeams(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) (map[string]*Team, error) {
+	return teamResource.ListWithCapabilities(ctx, c, principal.ref(), opts...)
+}
+
+// OffDay reports permissions the principal has on the offday.
+type OffDay struct {
+	Id     string
+	View   bool
+	Edit   bool
+	Delete bool
+}
+
+var offDayResource = newResource(
+	definitionOffDay,
+	[]string{
+		permissionView,
+		permissionEdit,
+		permissionDelete,
+	},
+	func(id string, granted map[string]bool) *OffDay {
+		return &OffDay{
+			Id:     id,
+			View:   granted[permissionView],
+			Edit:   granted[permissionEdit],
+			Delete: granted[permissionDelete],
+		}
+	},
+)
+
+// RelationOffDayOrganization builds offday#organization@organization relationship.
+func RelationOffDayOrganization(
+	offDayId string,
+	organizationId string,
+) *pb.Relationship {
+	return offDayResource.Relation(offDayId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// FilterOffDayOrganization matches offday#organization@organization relationships
+// of the offday, or of any offday if the id is empty.
+func FilterOffDayOrganization(offDayId string) *pb.RelationshipFilter {
+	return offDayResource.Filter(offDayId, relationOrganization, definitionOrganization)
+}
+
+// WriteOffDayOrganization writes offday#organization@organization relationship.
+func (c *Client) WriteOffDayOrganization(
+	ctx context.Context,
+	offDayId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return offDayResource.Write(ctx, c, offDayId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// DeleteOffDayOrganization deletes offday#organization@organization relationship.
+func (c *Client) DeleteOffDayOrganization(
+	ctx context.Context,
+	offDayId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return offDayResource.Delete(ctx, c, offDayId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// CanEditOffDay checks if the principal has offday#edit permission.
+func (c *Client) CanEditOffDay(
+	ctx context.Context,
+	offDayId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return offDayResource.Can(ctx, c, offDayId, permissionEdit, principal.ref(), opts...)
+}
+
+// ExplainEditOffDay checks the permission like CanEditOffDay
+// and returns the trace explaining the result.
+func (c *Client) ExplainEditOffDay(
+	ctx context.Context,
+	offDayId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return offDayResource.Explain(ctx, c, offDayId, permissionEdit, principal.ref(), opts...)
+}
+
+// LookupEditOffDayMembers streams member subjects
+// which have offday#edit permission.
+func (c *Client) LookupEditOffDayMembers(
+	ctx context.Context,
+	offDayId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return offDayResource.LookupSubjects(ctx, c, offDayId, permissionEdit, definitionMember, fn, opts...)
+}
+
+// ListEditOffDays returns ids of offday resources
+// the principal has edit permission on.
+func (c *Client) ListEditOffDays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return offDayResource.List(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// IterateEditOffDays iterates over ids of offday resources
+// the principal has edit permission on.
+func (c *Client) IterateEditOffDays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return offDayResource.Iterate(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// CanViewOffDay checks if the principal has offday#view permission.
+func (c *Client) CanViewOffDay(
+	ctx context.Context,
+	offDayId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return offDayResource.Can(ctx, c, offDayId, permissionView, principal.ref(), opts...)
+}
+
+// ExplainViewOffDay checks the permission like CanViewOffDay
+// and returns the trace explaining the result.
+func (c *Client) ExplainViewOffDay(
+	ctx context.Context,
+	offDayId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return offDayResource.Explain(ctx, c, offDayId, permissionView, principal.ref(), opts...)
+}
+
+// LookupViewOffDayMembers streams member subjects
+// which have offday#view permission.
+func (c *Client) LookupViewOffDayMembers(
+	ctx context.Context,
+	offDayId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return offDayResource.LookupSubjects(ctx, c, offDayId, permissionView, definitionMember, fn, opts...)
+}
+
+// ListViewOffDays returns ids of offday resources
+// the principal has view permission on.
+func (c *Client) ListViewOffDays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return offDayResource.List(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// IterateViewOffDays iterates over ids of offday resources
+// the principal has view permission on.
+func (c *Client) IterateViewOffDays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return offDayResource.Iterate(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// CanDeleteOffDay checks if the principal has offday#delete permission.
+func (c *Client) CanDeleteOffDay(
+	ctx context.Context,
+	offDayId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return offDayResource.Can(ctx, c, offDayId, permissionDelete, principal.ref(), opts...)
+}
+
+// ExplainDeleteOffDay checks the permission like CanDeleteOffDay
+// and returns the trace explaining the result.
+func (c *Client) ExplainDeleteOffDay(
+	ctx context.Context,
+	offDayId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return offDayResource.Explain(ctx, c, offDayId, permissionDelete, principal.ref(), opts...)
+}
+
+// LookupDeleteOffDayMembers streams member subjects
+// which have offday#delete permission.
+func (c *Client) LookupDeleteOffDayMembers(
+	ctx context.Context,
+	offDayId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return offDayResource.LookupSubjects(ctx, c, offDayId, permissionDelete, definitionMember, fn, opts...)
+}
+
+// ListDeleteOffDays returns ids of offday resources
+// the principal has delete permission on.
+func (c *Client) ListDeleteOffDays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return offDayResource.List(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// IterateDeleteOffDays iterates over ids of offday resources
+// the principal has delete permission on.
+func (c *Client) IterateDeleteOffDays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return offDayResource.Iterate(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// ListOffDays returns capabilities of offday resources
+// the principal has access to, keyed by resource id.
+func (c *Client) ListOffDays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) (map[string]*OffDay, error) {
+	return offDayResource.ListWithCapabilities(ctx, c, principal.ref(), opts...)
+}
+
+// Holiday reports permissions the principal has on the holiday.
+type Holiday struct {
+	Id     string
+	View   bool
+	Edit   bool
+	Delete bool
+}
+
+var holidayResource = newResource(
+	definitionHoliday,
+	[]string{
+		permissionView,
+		permissionEdit,
+		permissionDelete,
+	},
+	func(id string, granted map[string]bool) *Holiday {
+		return &Holiday{
+			Id:     id,
+			View:   granted[permissionView],
+			Edit:   granted[permissionEdit],
+			Delete: granted[permissionDelete],
+		}
+	},
+)
+
+// RelationHolidayOrganization builds holiday#organization@organization relationship.
+func RelationHolidayOrganization(
+	holidayId string,
+	organizationId string,
+) *pb.Relationship {
+	return holidayResource.Relation(holidayId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// FilterHolidayOrganization matches holiday#organization@organization relationships
+// of the holiday, or of any holiday if the id is empty.
+func FilterHolidayOrganization(holidayId string) *pb.RelationshipFilter {
+	return holidayResource.Filter(holidayId, relationOrganization, definitionOrganization)
+}
+
+// WriteHolidayOrganization writes holiday#organization@organization relationship.
+func (c *Client) WriteHolidayOrganization(
+	ctx context.Context,
+	holidayId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return holidayResource.Write(ctx, c, holidayId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// DeleteHolidayOrganization deletes holiday#organization@organization relationship.
+func (c *Client) DeleteHolidayOrganization(
+	ctx context.Context,
+	holidayId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return holidayResource.Delete(ctx, c, holidayId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// CanEditHoliday checks if the principal has holiday#edit permission.
+func (c *Client) CanEditHoliday(
+	ctx context.Context,
+	holidayId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return holidayResource.Can(ctx, c, holidayId, permissionEdit, principal.ref(), opts...)
+}
+
+// ExplainEditHoliday checks the permission like CanEditHoliday
+// and returns the trace explaining the result.
+func (c *Client) ExplainEditHoliday(
+	ctx context.Context,
+	holidayId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return holidayResource.Explain(ctx, c, holidayId, permissionEdit, principal.ref(), opts...)
+}
+
+// LookupEditHolidayMembers streams member subjects
+// which have holiday#edit permission.
+func (c *Client) LookupEditHolidayMembers(
+	ctx context.Context,
+	holidayId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return holidayResource.LookupSubjects(ctx, c, holidayId, permissionEdit, definitionMember, fn, opts...)
+}
+
+// ListEditHolidays returns ids of holiday resources
+// the principal has edit permission on.
+func (c *Client) ListEditHolidays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return holidayResource.List(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// IterateEditHolidays iterates over ids of holiday resources
+// the principal has edit permission on.
+func (c *Client) IterateEditHolidays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return holidayResource.Iterate(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// CanViewHoliday checks if the principal has holiday#view permission.
+func (c *Client) CanViewHoliday(
+	ctx context.Context,
+	holidayId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return holidayResource.Can(ctx, c, holidayId, permissionView, principal.ref(), opts...)
+}
+
+// ExplainViewHoliday checks the permission like CanViewHoliday
+// and returns the trace explaining the result.
+func (c *Client) ExplainViewHoliday(
+	ctx context.Context,
+	holidayId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return holidayResource.Explain(ctx, c, holidayId, permissionView, principal.ref(), opts...)
+}
+
+// LookupViewHolidayMembers streams member subjects
+// which have holiday#view permission.
+func (c *Client) LookupViewHolidayMembers(
+	ctx context.Context,
+	holidayId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return holidayResource.LookupSubjects(ctx, c, holidayId, permissionView, definitionMember, fn, opts...)
+}
+
+// ListViewHolidays returns ids of holiday resources
+// the principal has view permission on.
+func (c *Client) ListViewHolidays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return holidayResource.List(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// IterateViewHolidays iterates over ids of holiday resources
+// the principal has view permission on.
+func (c *Client) IterateViewHolidays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return holidayResource.Iterate(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// CanDeleteHoliday checks if the principal has holiday#delete permission.
+func (c *Client) CanDeleteHoliday(
+	ctx context.Context,
+	holidayId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return holidayResource.Can(ctx, c, holidayId, permissionDelete, principal.ref(), opts...)
+}
+
+// ExplainDeleteHoliday checks the permission like CanDeleteHoliday
+// and returns the trace explaining the result.
+func (c *Client) ExplainDeleteHoliday(
+	ctx context.Context,
+	holidayId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return holidayResource.Explain(ctx, c, holidayId, permissionDelete, principal.ref(), opts...)
+}
+
+// LookupDeleteHolidayMembers streams member subjects
+// which have holiday#delete permission.
+func (c *Client) LookupDeleteHolidayMembers(
+	ctx context.Context,
+	holidayId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return holidayResource.LookupSubjects(ctx, c, holidayId, permissionDelete, definitionMember, fn, opts...)
+}
+
+// ListDeleteHolidays returns ids of holiday resources
+// the principal has delete permission on.
+func (c *Client) ListDeleteHolidays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return holidayResource.List(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// IterateDeleteHolidays iterates over ids of holiday resources
+// the principal has delete permission on.
+func (c *Client) IterateDeleteHolidays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return holidayResource.Iterate(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// ListHolidays returns capabilities of holiday resources
+// the principal has access to, keyed by resource id.
+func (c *Client) ListHolidays(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) (map[string]*Holiday, error) {
+	return holidayResource.ListWithCapabilities(ctx, c, principal.ref(), opts...)
+}
+
+// Password reports permissions the principal has on the password.
+type Password struct {
+	Id     string
+	View   bool
+	Edit   bool
+	Delete bool
+}
+
+var passwordResource = newResource(
+	definitionPassword,
+	[]string{
+		permissionView,
+		permissionEdit,
+		permissionDelete,
+	},
+	func(id string, granted map[string]bool) *Password {
+		return &Password{
+			Id:     id,
+			View:   granted[permissionView],
+			Edit:   granted[permissionEdit],
+			Delete: granted[permissionDelete],
+		}
+	},
+)
+
+// RelationPasswordOrganization builds password#organization@organization relationship.
+func RelationPasswordOrganization(
+	passwordId string,
+	organizationId string,
+) *pb.Relationship {
+	return passwordResource.Relation(passwordId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// FilterPasswordOrganization matches password#organization@organization relationships
+// of the password, or of any password if the id is empty.
+func FilterPasswordOrganization(passwordId string) *pb.RelationshipFilter {
+	return passwordResource.Filter(passwordId, relationOrganization, definitionOrganization)
+}
+
+// WritePasswordOrganization writes password#organization@organization relationship.
+func (c *Client) WritePasswordOrganization(
+	ctx context.Context,
+	passwordId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return passwordResource.Write(ctx, c, passwordId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// DeletePasswordOrganization deletes password#organization@organization relationship.
+func (c *Client) DeletePasswordOrganization(
+	ctx context.Context,
+	passwordId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return passwordResource.Delete(ctx, c, passwordId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// CanEditPassword checks if the principal has password#edit permission.
+func (c *Client) CanEditPassword(
+	ctx context.Context,
+	passwordId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return passwordResource.Can(ctx, c, passwordId, permissionEdit, principal.ref(), opts...)
+}
+
+// ExplainEditPassword checks the permission like CanEditPassword
+// and returns the trace explaining the result.
+func (c *Client) ExplainEditPassword(
+	ctx context.Context,
+	passwordId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return passwordResource.Explain(ctx, c, passwordId, permissionEdit, principal.ref(), opts...)
+}
+
+// LookupEditPasswordMembers streams member subjects
+// which have password#edit permission.
+func (c *Client) LookupEditPasswordMembers(
+	ctx context.Context,
+	passwordId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return passwordResource.LookupSubjects(ctx, c, passwordId, permissionEdit, definitionMember, fn, opts...)
+}
+
+// ListEditPasswords returns ids of password resources
+// the principal has edit permission on.
+func (c *Client) ListEditPasswords(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return passwordResource.List(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// IterateEditPasswords iterates over ids of password resources
+// the principal has edit permission on.
+func (c *Client) IterateEditPasswords(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return passwordResource.Iterate(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// CanViewPassword checks if the principal has password#view permission.
+func (c *Client) CanViewPassword(
+	ctx context.Context,
+	passwordId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return passwordResource.Can(ctx, c, passwordId, permissionView, principal.ref(), opts...)
+}
+
+// ExplainViewPassword checks the permission like CanViewPassword
+// and returns the trace explaining the result.
+func (c *Client) ExplainViewPassword(
+	ctx context.Context,
+	passwordId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return passwordResource.Explain(ctx, c, passwordId, permissionView, principal.ref(), opts...)
+}
+
+// LookupViewPasswordMembers streams member subjects
+// which have password#view permission.
+func (c *Client) LookupViewPasswordMembers(
+	ctx context.Context,
+	passwordId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return passwordResource.LookupSubjects(ctx, c, passwordId, permissionView, definitionMember, fn, opts...)
+}
+
+// ListViewPasswords returns ids of password resources
+// the principal has view permission on.
+func (c *Client) ListViewPasswords(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return passwordResource.List(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// IterateViewPasswords iterates over ids of password resources
+// the principal has view permission on.
+func (c *Client) IterateViewPasswords(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return passwordResource.Iterate(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// CanDeletePassword checks if the principal has password#delete permission.
+func (c *Client) CanDeletePassword(
+	ctx context.Context,
+	passwordId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return passwordResource.Can(ctx, c, passwordId, permissionDelete, principal.ref(), opts...)
+}
+
+// ExplainDeletePassword checks the permission like CanDeletePassword
+// and returns the trace explaining the result.
+func (c *Client) ExplainDeletePassword(
+	ctx context.Context,
+	passwordId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return passwordResource.Explain(ctx, c, passwordId, permissionDelete, principal.ref(), opts...)
+}
+
+// LookupDeletePasswordMembers streams member subjects
+// which have password#delete permission.
+func (c *Client) LookupDeletePasswordMembers(
+	ctx context.Context,
+	passwordId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return passwordResource.LookupSubjects(ctx, c, passwordId, permissionDelete, definitionMember, fn, opts...)
+}
+
+// ListDeletePasswords returns ids of password resources
+// the principal has delete permission on.
+func (c *Client) ListDeletePasswords(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return passwordResource.List(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// IterateDeletePasswords iterates over ids of password resources
+// the principal has delete permission on.
+func (c *Client) IterateDeletePasswords(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return passwordResource.Iterate(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// ListPasswords returns capabilities of password resources
+// the principal has access to, keyed by resource id.
+func (c *Client) ListPasswords(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) (map[string]*Password, error) {
+	return passwordResource.ListWithCapabilities(ctx, c, principal.ref(), opts...)
+}
+
+// Contact reports permissions the principal has on the contact.
+type Contact struct {
+	Id     string
+	View   bool
+	Edit   bool
+	Delete bool
+}
+
+var contactResource = newResource(
+	definitionContact,
+	[]string{
+		permissionView,
+		permissionEdit,
+		permissionDelete,
+	},
+	func(id string, granted map[string]bool) *Contact {
+		return &Contact{
+			Id:     id,
+			View:   granted[permissionView],
+			Edit:   granted[permissionEdit],
+			Delete: granted[permissionDelete],
+		}
+	},
+)
+
+// RelationContactOrganization builds contact#organization@organization relationship.
+func RelationContactOrganization(
+	contactId string,
+	organizationId string,
+) *pb.Relationship {
+	return contactResource.Relation(contactId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// FilterContactOrganization matches contact#organization@organization relationships
+// of the contact, or of any contact if the id is empty.
+func FilterContactOrganization(contactId string) *pb.RelationshipFilter {
+	return contactResource.Filter(contactId, relationOrganization, definitionOrganization)
+}
+
+// WriteContactOrganization writes contact#organization@organization relationship.
+func (c *Client) WriteContactOrganization(
+	ctx context.Context,
+	contactId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return contactResource.Write(ctx, c, contactId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// DeleteContactOrganization deletes contact#organization@organization relationship.
+func (c *Client) DeleteContactOrganization(
+	ctx context.Context,
+	contactId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return contactResource.Delete(ctx, c, contactId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// RelationContactOwner builds contact#owner@member relationship.
+func RelationContactOwner(
+	contactId string,
+	memberId string,
+) *pb.Relationship {
+	return contactResource.Relation(contactId, relationOwner, subRef(definitionMember, memberId))
+}
+
+// FilterContactOwner matches contact#owner@member relationships
+// of the contact, or of any contact if the id is empty.
+func FilterContactOwner(contactId string) *pb.RelationshipFilter {
+	return contactResource.Filter(contactId, relationOwner, definitionMember)
+}
+
+// WriteContactOwner writes contact#owner@member relationship.
+func (c *Client) WriteContactOwner(
+	ctx context.Context,
+	contactId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return contactResource.Write(ctx, c, contactId, relationOwner, subRef(definitionMember, memberId))
+}
+
+// DeleteContactOwner deletes contact#owner@member relationship.
+func (c *Client) DeleteContactOwner(
+	ctx context.Context,
+	contactId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return contactResource.Delete(ctx, c, contactId, relationOwner, subRef(definitionMember, memberId))
+}
+
+// LookupContactOwners streams member subjects of contact#owner relation.
+func (c *Client) LookupContactOwners(
+	ctx context.Context,
+	contactId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return contactResource.LookupSubjects(ctx, c, contactId, relationOwner, definitionMember, fn, opts...)
+}
+
+// CanEditContact checks if the principal has contact#edit permission.
+func (c *Client) CanEditContact(
+	ctx context.Context,
+	contactId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return contactResource.Can(ctx, c, contactId, permissionEdit, principal.ref(), opts...)
+}
+
+// ExplainEditContact checks the permission like CanEditContact
+// and returns the trace explaining the result.
+func (c *Client) ExplainEditContact(
+	ctx context.Context,
+	contactId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return contactResource.Explain(ctx, c, contactId, permissionEdit, principal.ref(), opts...)
+}
+
+// LookupEditContactMembers streams member subjects
+// which have contact#edit permission.
+func (c *Client) LookupEditContactMembers(
+	ctx context.Context,
+	contactId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return contactResource.LookupSubjects(ctx, c, contactId, permissionEdit, definitionMember, fn, opts...)
+}
+
+// ListEditContacts returns ids of contact resources
+// the principal has edit permission on.
+func (c *Client) ListEditContacts(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return contactResource.List(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// IterateEditContacts iterates over ids of contact resources
+// the principal has edit permission on.
+func (c *Client) IterateEditContacts(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return contactResource.Iterate(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// CanViewContact checks if the principal has contact#view permission.
+func (c *Client) CanViewContact(
+	ctx context.Context,
+	contactId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return contactResource.Can(ctx, c, contactId, permissionView, principal.ref(), opts...)
+}
+
+// ExplainViewContact checks the permission like CanViewContact
+// and returns the trace explaining the result.
+func (c *Client) ExplainViewContact(
+	ctx context.Context,
+	contactId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return contactResource.Explain(ctx, c, contactId, permissionView, principal.ref(), opts...)
+}
+
+// LookupViewContactMembers streams member subjects
+// which have contact#view permission.
+func (c *Client) LookupViewContactMembers(
+	ctx context.Context,
+	contactId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return contactResource.LookupSubjects(ctx, c, contactId, permissionView, definitionMember, fn, opts...)
+}
+
+// LookupViewContactApiKeys streams apikey subjects
+// which have contact#view permission.
+func (c *Client) LookupViewContactApiKeys(
+	ctx context.Context,
+	contactId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return contactResource.LookupSubjects(ctx, c, contactId, permissionView, definitionApiKey, fn, opts...)
+}
+
+// ListViewContacts returns ids of contact resources
+// the principal has view permission on.
+func (c *Client) ListViewContacts(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return contactResource.List(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// IterateViewContacts iterates over ids of contact resources
+// the principal has view permission on.
+func (c *Client) IterateViewContacts(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return contactResource.Iterate(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// CanDeleteContact checks if the principal has contact#delete permission.
+func (c *Client) CanDeleteContact(
+	ctx context.Context,
+	contactId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return contactResource.Can(ctx, c, contactId, permissionDelete, principal.ref(), opts...)
+}
+
+// ExplainDeleteContact checks the permission like CanDeleteContact
+// and returns the trace explaining the result.
+func (c *Client) ExplainDeleteContact(
+	ctx context.Context,
+	contactId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return contactResource.Explain(ctx, c, contactId, permissionDelete, principal.ref(), opts...)
+}
+
+// LookupDeleteContactMembers streams member subjects
+// which have contact#delete permission.
+func (c *Client) LookupDeleteContactMembers(
+	ctx context.Context,
+	contactId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return contactResource.LookupSubjects(ctx, c, contactId, permissionDelete, definitionMember, fn, opts...)
+}
+
+// ListDeleteContacts returns ids of contact resources
+// the principal has delete permission on.
+func (c *Client) ListDeleteContacts(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return contactResource.List(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// IterateDeleteContacts iterates over ids of contact resources
+// the principal has delete permission on.
+func (c *Client) IterateDeleteContacts(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return contactResource.Iterate(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// ListContacts returns capabilities of contact resources
+// the principal has access to, keyed by resource id.
+func (c *Client) ListContacts(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) (map[string]*Contact, error) {
+	return contactResource.ListWithCapabilities(ctx, c, principal.ref(), opts...)
+}
+
+// Inbox reports permissions the principal has on the inbox.
+type Inbox struct {
+	Id     string
+	View   bool
+	Edit   bool
+	Delete bool
+}
+
+var inboxResource = newResource(
+	definitionInbox,
+	[]string{
+		permissionView,
+		permissionEdit,
+		permissionDelete,
+	},
+	func(id string, granted map[string]bool) *Inbox {
+		return &Inbox{
+			Id:     id,
+			View:   granted[permissionView],
+			Edit:   granted[permissionEdit],
+			Delete: granted[permissionDelete],
+		}
+	},
+)
+
+// RelationInboxOrganization builds inbox#organization@organization relationship.
+func RelationInboxOrganization(
+	inboxId string,
+	organizationId string,
+) *pb.Relationship {
+	return inboxResource.Relation(inboxId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// FilterInboxOrganization matches inbox#organization@organization relationships
+// of the inbox, or of any inbox if the id is empty.
+func FilterInboxOrganization(inboxId string) *pb.RelationshipFilter {
+	return inboxResource.Filter(inboxId, relationOrganization, definitionOrganization)
+}
+
+// WriteInboxOrganization writes inbox#organization@organization relationship.
+func (c *Client) WriteInboxOrganization(
+	ctx context.Context,
+	inboxId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return inboxResource.Write(ctx, c, inboxId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// DeleteInboxOrganization deletes inbox#organization@organization relationship.
+func (c *Client) DeleteInboxOrganization(
+	ctx context.Context,
+	inboxId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return inboxResource.Delete(ctx, c, inboxId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// RelationInboxOwner builds inbox#owner@member relationship.
+func RelationInboxOwner(
+	inboxId string,
+	memberId string,
+) *pb.Relationship {
+	return inboxResource.Relation(inboxId, relationOwner, subRef(definitionMember, memberId))
+}
+
+// FilterInboxOwner matches inbox#owner@member relationships
+// of the inbox, or of any inbox if the id is empty.
+func FilterInboxOwner(inboxId string) *pb.RelationshipFilter {
+	return inboxResource.Filter(inboxId, relationOwner, definitionMember)
+}
+
+// WriteInboxOwner writes inbox#owner@member relationship.
+func (c *Client) WriteInboxOwner(
+	ctx context.Context,
+	inboxId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return inboxResource.Write(ctx, c, inboxId, relationOwner, subRef(definitionMember, memberId))
+}
+
+// DeleteInboxOwner deletes inbox#owner@member relationship.
+func (c *Client) DeleteInboxOwner(
+	ctx context.Context,
+	inboxId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return inboxResource.Delete(ctx, c, inboxId, relationOwner, subRef(definitionMember, memberId))
+}
+
+// LookupInboxOwners streams member subjects of inbox#owner relation.
+func (c *Client) LookupInboxOwners(
+	ctx context.Context,
+	inboxId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return inboxResource.LookupSubjects(ctx, c, inboxId, relationOwner, definitionMember, fn, opts...)
+}
+
+// CanEditInbox checks if the principal has inbox#edit permission.
+func (c *Client) CanEditInbox(
+	ctx context.Context,
+	inboxId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return inboxResource.Can(ctx, c, inboxId, permissionEdit, principal.ref(), opts...)
+}
+
+// ExplainEditInbox checks the permission like CanEditInbox
+// and returns the trace explaining the result.
+func (c *Client) ExplainEditInbox(
+	ctx context.Context,
+	inboxId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return inboxResource.Explain(ctx, c, inboxId, permissionEdit, principal.ref(), opts...)
+}
+
+// LookupEditInboxMembers streams member subjects
+// which have inbox#edit permission.
+func (c *Client) LookupEditInboxMembers(
+	ctx context.Context,
+	inboxId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return inboxResource.LookupSubjects(ctx, c, inboxId, permissionEdit, definitionMember, fn, opts...)
+}
+
+// ListEditInboxes returns ids of inbox resources
+// the principal has edit permission on.
+func (c *Client) ListEditInboxes(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return inboxResource.List(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// IterateEditInboxes iterates over ids of inbox resources
+// the principal has edit permission on.
+func (c *Client) IterateEditInboxes(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return inboxResource.Iterate(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// CanViewInbox checks if the principal has inbox#view permission.
+func (c *Client) CanViewInbox(
+	ctx context.Context,
+	inboxId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return inboxResource.Can(ctx, c, inboxId, permissionView, principal.ref(), opts...)
+}
+
+// ExplainViewInbox checks the permission like CanViewInbox
+// and returns the trace explaining the result.
+func (c *Client) ExplainViewInbox(
+	ctx context.Context,
+	inboxId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return inboxResource.Explain(ctx, c, inboxId, permissionView, principal.ref(), opts...)
+}
+
+// LookupViewInboxMembers streams member subjects
+// which have inbox#view permission.
+func (c *Client) LookupViewInboxMembers(
+	ctx context.Context,
+	inboxId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return inboxResource.LookupSubjects(ctx, c, inboxId, permissionView, definitionMember, fn, opts...)
+}
+
+// LookupViewInboxApiKeys streams apikey subjects
+// which have inbox#view permission.
+func (c *Client) LookupViewInboxApiKeys(
+	ctx context.Context,
+	inboxId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return inboxResource.LookupSubjects(ctx, c, inboxId, permissionView, definitionApiKey, fn, opts...)
+}
+
+// ListViewInboxes returns ids of inbox resources
+// the principal has view permission on.
+func (c *Client) ListViewInboxes(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return inboxResource.List(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// IterateViewInboxes iterates over ids of inbox resources
+// the principal has view permission on.
+func (c *Client) IterateViewInboxes(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return inboxResource.Iterate(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// CanDeleteInbox checks if the principal has inbox#delete permission.
+func (c *Client) CanDeleteInbox(
+	ctx context.Context,
+	inboxId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return inboxResource.Can(ctx, c, inboxId, permissionDelete, principal.ref(), opts...)
+}
+
+// ExplainDeleteInbox checks the permission like CanDeleteInbox
+// and returns the trace explaining the result.
+func (c *Client) ExplainDeleteInbox(
+	ctx context.Context,
+	inboxId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return inboxResource.Explain(ctx, c, inboxId, permissionDelete, principal.ref(), opts...)
+}
+
+// LookupDeleteInboxMembers streams member subjects
+// which have inbox#delete permission.
+func (c *Client) LookupDeleteInboxMembers(
+	ctx context.Context,
+	inboxId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return inboxResource.LookupSubjects(ctx, c, inboxId, permissionDelete, definitionMember, fn, opts...)
+}
+
+// ListDeleteInboxes returns ids of inbox resources
+// the principal has delete permission on.
+func (c *Client) ListDeleteInboxes(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return inboxResource.List(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// IterateDeleteInboxes iterates over ids of inbox resources
+// the principal has delete permission on.
+func (c *Client) IterateDeleteInboxes(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return inboxResource.Iterate(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// ListInboxes returns capabilities of inbox resources
+// the principal has access to, keyed by resource id.
+func (c *Client) ListInboxes(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) (map[string]*Inbox, error) {
+	return inboxResource.ListWithCapabilities(ctx, c, principal.ref(), opts...)
+}
+
+// Sequence reports permissions the principal has on the sequence.
+type Sequence struct {
+	Id             string
+	View           bool
+	Edit           bool
+	Delete         bool
+	UploadContact  bool
+	CreateCallStep bool
+}
+
+var sequenceResource = newResource(
+	definitionSequence,
+	[]string{
+		permissionView,
+		permissionEdit,
+		permissionDelete,
+		permissionUploadContact,
+		permissionCreateCallStep,
+	},
+	func(id string, granted map[string]bool) *Sequence {
+		return &Sequence{
+			Id:             id,
+			View:           granted[permissionView],
+			Edit:           granted[permissionEdit],
+			Delete:         granted[permissionDelete],
+			UploadContact:  granted[permissionUploadContact],
+			CreateCallStep: granted[permissionCreateCallStep],
+		}
+	},
+)
+
+// RelationSequenceOrganization builds sequence#organization@organization relationship.
+func RelationSequenceOrganization(
+	sequenceId string,
+	organizationId string,
+) *pb.Relationship {
+	return sequenceResource.Relation(sequenceId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// FilterSequenceOrganization matches sequence#organization@organization relationships
+// of the sequence, or of any sequence if the id is empty.
+func FilterSequenceOrganization(sequenceId string) *pb.RelationshipFilter {
+	return sequenceResource.Filter(sequenceId, relationOrganization, definitionOrganization)
+}
+
+// WriteSequenceOrganization writes sequence#organization@organization relationship.
+func (c *Client) WriteSequenceOrganization(
+	ctx context.Context,
+	sequenceId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Write(ctx, c, sequenceId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// DeleteSequenceOrganization deletes sequence#organization@organization relationship.
+func (c *Client) DeleteSequenceOrganization(
+	ctx context.Context,
+	sequenceId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Delete(ctx, c, sequenceId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// RelationSequenceOwner builds sequence#owner@member relationship.
+func RelationSequenceOwner(
+	sequenceId string,
+	memberId string,
+) *pb.Relationship {
+	return sequenceResource.Relation(sequenceId, relationOwner, subRef(definitionMember, memberId))
+}
+
+// FilterSequenceOwner matches sequence#owner@member relationships
+// of the sequence, or of any sequence if the id is empty.
+func FilterSequenceOwner(sequenceId string) *pb.RelationshipFilter {
+	return sequenceResource.Filter(sequenceId, relationOwner, definitionMember)
+}
+
+// WriteSequenceOwner writes sequence#owner@member relationship.
+func (c *Client) WriteSequenceOwner(
+	ctx context.Context,
+	sequenceId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Write(ctx, c, sequenceId, relationOwner, subRef(definitionMember, memberId))
+}
+
+// DeleteSequenceOwner deletes sequence#owner@member relationship.
+func (c *Client) DeleteSequenceOwner(
+	ctx context.Context,
+	sequenceId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Delete(ctx, c, sequenceId, relationOwner, subRef(definitionMember, memberId))
+}
+
+// LookupSequenceOwners streams member subjects of sequence#owner relation.
+func (c *Client) LookupSequenceOwners(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, relationOwner, definitionMember, fn, opts...)
+}
+
+// RelationSequenceSender builds sequence#sender@member relationship.
+func RelationSequenceSender(
+	sequenceId string,
+	memberId string,
+) *pb.Relationship {
+	return sequenceResource.Relation(sequenceId, relationSender, subRef(definitionMember, memberId))
+}
+
+// FilterSequenceSender matches sequence#sender@member relationships
+// of the sequence, or of any sequence if the id is empty.
+func FilterSequenceSender(sequenceId string) *pb.RelationshipFilter {
+	return sequenceResource.Filter(sequenceId, relationSender, definitionMember)
+}
+
+// WriteSequenceSender writes sequence#sender@member relationship.
+func (c *Client) WriteSequenceSender(
+	ctx context.Context,
+	sequenceId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Write(ctx, c, sequenceId, relationSender, subRef(definitionMember, memberId))
+}
+
+// DeleteSequenceSender deletes sequence#sender@member relationship.
+func (c *Client) DeleteSequenceSender(
+	ctx context.Context,
+	sequenceId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Delete(ctx, c, sequenceId, relationSender, subRef(definitionMember, memberId))
+}
+
+// LookupSequenceSenders streams member subjects of sequence#sender relation.
+func (c *Client) LookupSequenceSenders(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, relationSender, definitionMember, fn, opts...)
+}
+
+// RelationSequenceSenderTeam builds sequence#sender@team relationship.
+func RelationSequenceSenderTeam(
+	sequenceId string,
+	teamId string,
+) *pb.Relationship {
+	return sequenceResource.Relation(sequenceId, relationSender, subRef(definitionTeam, teamId))
+}
+
+// FilterSequenceSenderTeam matches sequence#sender@team relationships
+// of the sequence, or of any sequence if the id is empty.
+func FilterSequenceSenderTeam(sequenceId string) *pb.RelationshipFilter {
+	return sequenceResource.Filter(sequenceId, relationSender, definitionTeam)
+}
+
+// WriteSequenceSenderTeam writes sequence#sender@team relationship.
+func (c *Client) WriteSequenceSenderTeam(
+	ctx context.Context,
+	sequenceId string,
+	teamId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Write(ctx, c, sequenceId, relationSender, subRef(definitionTeam, teamId))
+}
+
+// DeleteSequenceSenderTeam deletes sequence#sender@team relationship.
+func (c *Client) DeleteSequenceSenderTeam(
+	ctx context.Context,
+	sequenceId string,
+	teamId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Delete(ctx, c, sequenceId, relationSender, subRef(definitionTeam, teamId))
+}
+
+// LookupSequenceSenderTeams streams team subjects of sequence#sender relation.
+func (c *Client) LookupSequenceSenderTeams(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, relationSender, definitionTeam, fn, opts...)
+}
+
+// RelationSequenceViewer builds sequence#viewer@member relationship.
+func RelationSequenceViewer(
+	sequenceId string,
+	memberId string,
+) *pb.Relationship {
+	return sequenceResource.Relation(sequenceId, relationViewer, subRef(definitionMember, memberId))
+}
+
+// FilterSequenceViewer matches sequence#viewer@member relationships
+// of the sequence, or of any sequence if the id is empty.
+func FilterSequenceViewer(sequenceId string) *pb.RelationshipFilter {
+	return sequenceResource.Filter(sequenceId, relationViewer, definitionMember)
+}
+
+// WriteSequenceViewer writes sequence#viewer@member relationship.
+func (c *Client) WriteSequenceViewer(
+	ctx context.Context,
+	sequenceId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Write(ctx, c, sequenceId, relationViewer, subRef(definitionMember, memberId))
+}
+
+// DeleteSequenceViewer deletes sequence#viewer@member relationship.
+func (c *Client) DeleteSequenceViewer(
+	ctx context.Context,
+	sequenceId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Delete(ctx, c, sequenceId, relationViewer, subRef(definitionMember, memberId))
+}
+
+// LookupSequenceViewers streams member subjects of sequence#viewer relation.
+func (c *Client) LookupSequenceViewers(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, relationViewer, definitionMember, fn, opts...)
+}
+
+// RelationSequenceEditor builds sequence#editor@member relationship.
+func RelationSequenceEditor(
+	sequenceId string,
+	memberId string,
+) *pb.Relationship {
+	return sequenceResource.Relation(sequenceId, relationEditor, subRef(definitionMember, memberId))
+}
+
+// FilterSequenceEditor matches sequence#editor@member relationships
+// of the sequence, or of any sequence if the id is empty.
+func FilterSequenceEditor(sequenceId string) *pb.RelationshipFilter {
+	return sequenceResource.Filter(sequenceId, relationEditor, definitionMember)
+}
+
+// WriteSequenceEditor writes sequence#editor@member relationship.
+func (c *Client) WriteSequenceEditor(
+	ctx context.Context,
+	sequenceId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Write(ctx, c, sequenceId, relationEditor, subRef(definitionMember, memberId))
+}
+
+// DeleteSequenceEditor deletes sequence#editor@member relationship.
+func (c *Client) DeleteSequenceEditor(
+	ctx context.Context,
+	sequenceId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Delete(ctx, c, sequenceId, relationEditor, subRef(definitionMember, memberId))
+}
+
+// LookupSequenceEditors streams member subjects of sequence#editor relation.
+func (c *Client) LookupSequenceEditors(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, relationEditor, definitionMember, fn, opts...)
+}
+
+// RelationSequenceContact builds sequence#contact@contact relationship.
+func RelationSequenceContact(
+	sequenceId string,
+	contactId string,
+) *pb.Relationship {
+	return sequenceResource.Relation(sequenceId, relationContact, subRef(definitionContact, contactId))
+}
+
+// FilterSequenceContact matches sequence#contact@contact relationships
+// of the sequence, or of any sequence if the id is empty.
+func FilterSequenceContact(sequenceId string) *pb.RelationshipFilter {
+	return sequenceResource.Filter(sequenceId, relationContact, definitionContact)
+}
+
+// WriteSequenceContact writes sequence#contact@contact relationship.
+func (c *Client) WriteSequenceContact(
+	ctx context.Context,
+	sequenceId string,
+	contactId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Write(ctx, c, sequenceId, relationContact, subRef(definitionContact, contactId))
+}
+
+// DeleteSequenceContact deletes sequence#contact@contact relationship.
+func (c *Client) DeleteSequenceContact(
+	ctx context.Context,
+	sequenceId string,
+	contactId string,
+) (*pb.ZedToken, error) {
+	return sequenceResource.Delete(ctx, c, sequenceId, relationContact, subRef(definitionContact, contactId))
+}
+
+// CanEditSequence checks if the principal has sequence#edit permission.
+func (c *Client) CanEditSequence(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.Can(ctx, c, sequenceId, permissionEdit, principal.ref(), opts...)
+}
+
+// ExplainEditSequence checks the permission like CanEditSequence
+// and returns the trace explaining the result.
+func (c *Client) ExplainEditSequence(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return sequenceResource.Explain(ctx, c, sequenceId, permissionEdit, principal.ref(), opts...)
+}
+
+// LookupEditSequenceMembers streams member subjects
+// which have sequence#edit permission.
+func (c *Client) LookupEditSequenceMembers(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, permissionEdit, definitionMember, fn, opts...)
+}
+
+// ListEditSequences returns ids of sequence resources
+// the principal has edit permission on.
+func (c *Client) ListEditSequences(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return sequenceResource.List(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// IterateEditSequences iterates over ids of sequence resources
+// the principal has edit permission on.
+func (c *Client) IterateEditSequences(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return sequenceResource.Iterate(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// CanViewSequence checks if the principal has sequence#view permission.
+func (c *Client) CanViewSequence(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.Can(ctx, c, sequenceId, permissionView, principal.ref(), opts...)
+}
+
+// ExplainViewSequence checks the permission like CanViewSequence
+// and returns the trace explaining the result.
+func (c *Client) ExplainViewSequence(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return sequenceResource.Explain(ctx, c, sequenceId, permissionView, principal.ref(), opts...)
+}
+
+// LookupViewSequenceMembers streams member subjects
+// which have sequence#view permission.
+func (c *Client) LookupViewSequenceMembers(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, permissionView, definitionMember, fn, opts...)
+}
+
+// LookupViewSequenceApiKeys streams apikey subjects
+// which have sequence#view permission.
+func (c *Client) LookupViewSequenceApiKeys(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, permissionView, definitionApiKey, fn, opts...)
+}
+
+// LookupViewSequenceTeams streams team subjects
+// which have sequence#view permission.
+func (c *Client) LookupViewSequenceTeams(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, permissionView, definitionTeam, fn, opts...)
+}
+
+// ListViewSequences returns ids of sequence resources
+// the principal has view permission on.
+func (c *Client) ListViewSequences(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return sequenceResource.List(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// IterateViewSequences iterates over ids of sequence resources
+// the principal has view permission on.
+func (c *Client) IterateViewSequences(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return sequenceResource.Iterate(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// CanDeleteSequence checks if the principal has sequence#delete permission.
+func (c *Client) CanDeleteSequence(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.Can(ctx, c, sequenceId, permissionDelete, principal.ref(), opts...)
+}
+
+// ExplainDeleteSequence checks the permission like CanDeleteSequence
+// and returns the trace explaining the result.
+func (c *Client) ExplainDeleteSequence(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return sequenceResource.Explain(ctx, c, sequenceId, permissionDelete, principal.ref(), opts...)
+}
+
+// LookupDeleteSequenceMembers streams member subjects
+// which have sequence#delete permission.
+func (c *Client) LookupDeleteSequenceMembers(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, permissionDelete, definitionMember, fn, opts...)
+}
+
+// ListDeleteSequences returns ids of sequence resources
+// the principal has delete permission on.
+func (c *Client) ListDeleteSequences(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return sequenceResource.List(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// IterateDeleteSequences iterates over ids of sequence resources
+// the principal has delete permission on.
+func (c *Client) IterateDeleteSequences(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return sequenceResource.Iterate(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// CanUploadSequenceContact checks if the principal has sequence#upload_contact permission.
+func (c *Client) CanUploadSequenceContact(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.Can(ctx, c, sequenceId, permissionUploadContact, principal.ref(), opts...)
+}
+
+// ExplainUploadSequenceContact checks the permission like CanUploadSequenceContact
+// and returns the trace explaining the result.
+func (c *Client) ExplainUploadSequenceContact(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return sequenceResource.Explain(ctx, c, sequenceId, permissionUploadContact, principal.ref(), opts...)
+}
+
+// LookupUploadSequenceContactMembers streams member subjects
+// which have sequence#upload_contact permission.
+func (c *Client) LookupUploadSequenceContactMembers(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, permissionUploadContact, definitionMember, fn, opts...)
+}
+
+// LookupUploadSequenceContactApiKeys streams apikey subjects
+// which have sequence#upload_contact permission.
+func (c *Client) LookupUploadSequenceContactApiKeys(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, permissionUploadContact, definitionApiKey, fn, opts...)
+}
+
+// ListUploadContactSequences returns ids of sequence resources
+// the principal has upload_contact permission on.
+func (c *Client) ListUploadContactSequences(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return sequenceResource.List(ctx, c, permissionUploadContact, principal.ref(), opts...)
+}
+
+// IterateUploadContactSequences iterates over ids of sequence resources
+// the principal has upload_contact permission on.
+func (c *Client) IterateUploadContactSequences(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return sequenceResource.Iterate(ctx, c, permissionUploadContact, principal.ref(), opts...)
+}
+
+// CanCreateSequenceCallStep checks if the principal has sequence#create_call_step permission.
+func (c *Client) CanCreateSequenceCallStep(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.Can(ctx, c, sequenceId, permissionCreateCallStep, principal.ref(), opts...)
+}
+
+// ExplainCreateSequenceCallStep checks the permission like CanCreateSequenceCallStep
+// and returns the trace explaining the result.
+func (c *Client) ExplainCreateSequenceCallStep(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return sequenceResource.Explain(ctx, c, sequenceId, permissionCreateCallStep, principal.ref(), opts...)
+}
+
+// LookupCreateSequenceCallStepMembers streams member subjects
+// which have sequence#create_call_step permission.
+func (c *Client) LookupCreateSequenceCallStepMembers(
+	ctx context.Context,
+	sequenceId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.LookupSubjects(ctx, c, sequenceId, permissionCreateCallStep, definitionMember, fn, opts...)
+}
+
+// ListCreateCallStepSequences returns ids of sequence resources
+// the principal has create_call_step permission on.
+func (c *Client) ListCreateCallStepSequences(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return sequenceResource.List(ctx, c, permissionCreateCallStep, principal.ref(), opts...)
+}
+
+// IterateCreateCallStepSequences iterates over ids of sequence resources
+// the principal has create_call_step permission on.
+func (c *Client) IterateCreateCallStepSequences(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return sequenceResource.Iterate(ctx, c, permissionCreateCallStep, principal.ref(), opts...)
+}
+
+// CanSequenceOrganizationAdmin checks if the principal has sequence#organization_admin permission.
+func (c *Client) CanSequenceOrganizationAdmin(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.Can(ctx, c, sequenceId, permissionOrganizationAdmin, principal.ref(), opts...)
+}
+
+// ExplainSequenceOrganizationAdmin checks the permission like CanSequenceOrganizationAdmin
+// and returns the trace explaining the result.
+func (c *Client) ExplainSequenceOrganizationAdmin(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return sequenceResource.Explain(ctx, c, sequenceId, permissionOrganizationAdmin, principal.ref(), opts...)
+}
+
+// CanSequenceOrganizationApiKey checks if the principal has sequence#organization_apikey permission.
+func (c *Client) CanSequenceOrganizationApiKey(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.Can(ctx, c, sequenceId, permissionOrganizationApiKey, principal.ref(), opts...)
+}
+
+// ExplainSequenceOrganizationApiKey checks the permission like CanSequenceOrganizationApiKey
+// and returns the trace explaining the result.
+func (c *Client) ExplainSequenceOrganizationApiKey(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return sequenceResource.Explain(ctx, c, sequenceId, permissionOrganizationApiKey, principal.ref(), opts...)
+}
+
+// CanOrganizationSequenceAccess checks if the principal has sequence#organization_access permission.
+func (c *Client) CanOrganizationSequenceAccess(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return sequenceResource.Can(ctx, c, sequenceId, permissionOrganizationAccess, principal.ref(), opts...)
+}
+
+// ExplainOrganizationSequenceAccess checks the permission like CanOrganizationSequenceAccess
+// and returns the trace explaining the result.
+func (c *Client) ExplainOrganizationSequenceAccess(
+	ctx context.Context,
+	sequenceId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return sequenceResource.Explain(ctx, c, sequenceId, permissionOrganizationAccess, principal.ref(), opts...)
+}
+
+// ListSequences returns capabilities of sequence resources
+// the principal has access to, keyed by resource id.
+func (c *Client) ListSequences(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) (map[string]*Sequence, error) {
+	return sequenceResource.ListWithCapabilities(ctx, c, principal.ref(), opts...)
+}
+
+// SequenceAction reports permissions the principal has on the sequence/action.
+type SequenceAction struct {
+	Id   string
+	View bool
+	Edit bool
+}
+
+var sequenceActionResource = newResource(
+	definitionSequenceAction,
+	[]string{
+		permissionView,
+		permissionEdit,
+	},
+	func(id string, granted map[string]bool) *SequenceAction {
+		return &SequenceAction{
+			Id:   id,
+			View: granted[permissionView],
+			Edit: granted[permissionEdit],
+		}
+	},
+)
+
+// RelationSequenceActionSequence builds sequence/action#sequence@sequence relationship.
+func RelationSequenceActionSequence(
+	sequenceActionId string,
+	sequenceId string,
+) *pb.Relationship {
+	return sequenceActionResource.Relation(sequenceActionId, relationSequence, subRef(definitionSequence, sequenceId))
+}
+
+// FilterSequenceActionSequence matches sequence/action#sequence@sequence relationships
+// of the sequence/action, or of any sequence/action if the id is empty.
+func FilterSequenceActionSequence(sequenceActionId string) *pb.RelationshipFilter {
+	return sequenceActionResource.Filter(sequenceActionId, relationSequence, definitionSequence)
+}
+
+// WriteSequenceActionSequence writes sequence/action#sequence@sequence relationship.
+func (c *Client) WriteSequenceActionSequence(
+	ctx context.Context,
+	sequenceActionId string,
+	sequenceId string,
+) (*pb.ZedToken, error) {
+	return sequenceActionResource.Write(ctx, c, sequenceActionId, relationSequence, subRef(definitionSequence, sequenceId))
+}
+
+// DeleteSequenceActionSequence deletes sequence/action#sequence@sequence relationship.
+func (c *Client) DeleteSequenceActionSequence(
+	ctx context.Context,
+	sequenceActionId string,
+	sequenceId string,
+) (*pb.ZedToken, error) {
+	return sequenceActionResource.Delete(ctx, c, sequenceActionId, relationSequence, subRef(definitionSequence, sequenceId))
+}
+
+// RelationSequenceActionAssignee builds sequence/action#assignee@member relationship.
+func RelationSequenceActionAssignee(
+	sequenceActionId string,
+	memberId string,
+) *pb.Relationship {
+	return sequenceActionResource.Relation(sequenceActionId, relationAssignee, subRef(definitionMember, memberId))
+}
+
+// FilterSequenceActionAssignee matches sequence/action#assignee@member relationships
+// of the sequence/action, or of any sequence/action if the id is empty.
+func FilterSequenceActionAssignee(sequenceActionId string) *pb.RelationshipFilter {
+	return sequenceActionResource.Filter(sequenceActionId, relationAssignee, definitionMember)
+}
+
+// AssignSequenceAction writes sequence/action#assignee@member relationship.
+func (c *Client) AssignSequenceAction(
+	ctx context.Context,
+	sequenceActionId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return sequenceActionResource.Write(ctx, c, sequenceActionId, relationAssignee, subRef(definitionMember, memberId))
+}
+
+// UnassignSequenceAction deletes sequence/action#assignee@member relationship.
+func (c *Client) UnassignSequenceAction(
+	ctx context.Context,
+	sequenceActionId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return sequenceActionResource.Delete(ctx, c, sequenceActionId, relationAssignee, subRef(definitionMember, memberId))
+}
+
+// LookupSequenceActionAssignees streams member subjects of sequence/action#assignee relation.
+func (c *Client) LookupSequenceActionAssignees(
+	ctx context.Context,
+	sequenceActionId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceActionResource.LookupSubjects(ctx, c, sequenceActionId, relationAssignee, definitionMember, fn, opts...)
+}
+
+// CanEditSequenceAction checks if the principal has sequence/action#edit permission.
+func (c *Client) CanEditSequenceAction(
+	ctx context.Context,
+	sequenceActionId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return sequenceActionResource.Can(ctx, c, sequenceActionId, permissionEdit, principal.ref(), opts...)
+}
+
+// ExplainEditSequenceAction checks the permission like CanEditSequenceAction
+// and returns the trace explaining the result.
+func (c *Client) ExplainEditSequenceAction(
+	ctx context.Context,
+	sequenceActionId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return sequenceActionResource.Explain(ctx, c, sequenceActionId, permissionEdit, principal.ref(), opts...)
+}
+
+// LookupEditSequenceActionMembers streams member subjects
+// which have sequence/action#edit permission.
+func (c *Client) LookupEditSequenceActionMembers(
+	ctx context.Context,
+	sequenceActionId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceActionResource.LookupSubjects(ctx, c, sequenceActionId, permissionEdit, definitionMember, fn, opts...)
+}
+
+// ListAssignedSequenceActions returns ids of sequence/action resources
+// the principal has edit permission on.
+func (c *Client) ListAssignedSequenceActions(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return sequenceActionResource.List(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// IterateAssignedSequenceActions iterates over ids of sequence/action resources
+// the principal has edit permission on.
+func (c *Client) IterateAssignedSequenceActions(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return sequenceActionResource.Iterate(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// CanViewSequenceAction checks if the principal has sequence/action#view permission.
+func (c *Client) CanViewSequenceAction(
+	ctx context.Context,
+	sequenceActionId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return sequenceActionResource.Can(ctx, c, sequenceActionId, permissionView, principal.ref(), opts...)
+}
+
+// ExplainViewSequenceAction checks the permission like CanViewSequenceAction
+// and returns the trace explaining the result.
+func (c *Client) ExplainViewSequenceAction(
+	ctx context.Context,
+	sequenceActionId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return sequenceActionResource.Explain(ctx, c, sequenceActionId, permissionView, principal.ref(), opts...)
+}
+
+// LookupViewSequenceActionMembers streams member subjects
+// which have sequence/action#view permission.
+func (c *Client) LookupViewSequenceActionMembers(
+	ctx context.Context,
+	sequenceActionId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceActionResource.LookupSubjects(ctx, c, sequenceActionId, permissionView, definitionMember, fn, opts...)
+}
+
+// LookupViewSequenceActionApiKeys streams apikey subjects
+// which have sequence/action#view permission.
+func (c *Client) LookupViewSequenceActionApiKeys(
+	ctx context.Context,
+	sequenceActionId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return sequenceActionResource.LookupSubjects(ctx, c, sequenceActionId, permissionView, definitionApiKey, fn, opts...)
+}
+
+// ListViewSequenceActions returns ids of sequence/action resources
+// the principal has view permission on.
+func (c *Client) ListViewSequenceActions(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return sequenceActionResource.List(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// IterateViewSequenceActions iterates over ids of sequence/action resources
+// the principal has view permission on.
+func (c *Client) IterateViewSequenceActions(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return sequenceActionResource.Iterate(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// ListSequenceActions returns capabilities of sequence/action resources
+// the principal has access to, keyed by resource id.
+func (c *Client) ListSequenceActions(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) (map[string]*SequenceAction, error) {
+	return sequenceActionResource.ListWithCapabilities(ctx, c, principal.ref(), opts...)
+}
+
+// Meeting reports permissions the principal has on the meeting.
+type Meeting struct {
+	Id     string
+	View   bool
+	Edit   bool
+	Delete bool
+}
+
+var meetingResource = newResource(
+	definitionMeeting,
+	[]string{
+		permissionView,
+		permissionEdit,
+		permissionDelete,
+	},
+	func(id string, granted map[string]bool) *Meeting {
+		return &Meeting{
+			Id:     id,
+			View:   granted[permissionView],
+			Edit:   granted[permissionEdit],
+			Delete: granted[permissionDelete],
+		}
+	},
+)
+
+// RelationMeetingOrganization builds meeting#organization@organization relationship.
+func RelationMeetingOrganization(
+	meetingId string,
+	organizationId string,
+) *pb.Relationship {
+	return meetingResource.Relation(meetingId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// FilterMeetingOrganization matches meeting#organization@organization relationships
+// of the meeting, or of any meeting if the id is empty.
+func FilterMeetingOrganization(meetingId string) *pb.RelationshipFilter {
+	return meetingResource.Filter(meetingId, relationOrganization, definitionOrganization)
+}
+
+// WriteMeetingOrganization writes meeting#organization@organization relationship.
+func (c *Client) WriteMeetingOrganization(
+	ctx context.Context,
+	meetingId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return meetingResource.Write(ctx, c, meetingId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// DeleteMeetingOrganization deletes meeting#organization@organization relationship.
+func (c *Client) DeleteMeetingOrganization(
+	ctx context.Context,
+	meetingId string,
+	organizationId string,
+) (*pb.ZedToken, error) {
+	return meetingResource.Delete(ctx, c, meetingId, relationOrganization, subRef(definitionOrganization, organizationId))
+}
+
+// RelationMeetingOwner builds meeting#owner@member relationship.
+func RelationMeetingOwner(
+	meetingId string,
+	memberId string,
+) *pb.Relationship {
+	return meetingResource.Relation(meetingId, relationOwner, subRef(definitionMember, memberId))
+}
+
+// FilterMeetingOwner matches meeting#owner@member relationships
+// of the meeting, or of any meeting if the id is empty.
+func FilterMeetingOwner(meetingId string) *pb.RelationshipFilter {
+	return meetingResource.Filter(meetingId, relationOwner, definitionMember)
+}
+
+// WriteMeetingOwner writes meeting#owner@member relationship.
+func (c *Client) WriteMeetingOwner(
+	ctx context.Context,
+	meetingId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return meetingResource.Write(ctx, c, meetingId, relationOwner, subRef(definitionMember, memberId))
+}
+
+// DeleteMeetingOwner deletes meeting#owner@member relationship.
+func (c *Client) DeleteMeetingOwner(
+	ctx context.Context,
+	meetingId string,
+	memberId string,
+) (*pb.ZedToken, error) {
+	return meetingResource.Delete(ctx, c, meetingId, relationOwner, subRef(definitionMember, memberId))
+}
+
+// LookupMeetingOwners streams member subjects of meeting#owner relation.
+func (c *Client) LookupMeetingOwners(
+	ctx context.Context,
+	meetingId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return meetingResource.LookupSubjects(ctx, c, meetingId, relationOwner, definitionMember, fn, opts...)
+}
+
+// CanEditMeeting checks if the principal has meeting#edit permission.
+func (c *Client) CanEditMeeting(
+	ctx context.Context,
+	meetingId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return meetingResource.Can(ctx, c, meetingId, permissionEdit, principal.ref(), opts...)
+}
+
+// ExplainEditMeeting checks the permission like CanEditMeeting
+// and returns the trace explaining the result.
+func (c *Client) ExplainEditMeeting(
+	ctx context.Context,
+	meetingId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return meetingResource.Explain(ctx, c, meetingId, permissionEdit, principal.ref(), opts...)
+}
+
+// LookupEditMeetingMembers streams member subjects
+// which have meeting#edit permission.
+func (c *Client) LookupEditMeetingMembers(
+	ctx context.Context,
+	meetingId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return meetingResource.LookupSubjects(ctx, c, meetingId, permissionEdit, definitionMember, fn, opts...)
+}
+
+// ListEditMeetings returns ids of meeting resources
+// the principal has edit permission on.
+func (c *Client) ListEditMeetings(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return meetingResource.List(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// IterateEditMeetings iterates over ids of meeting resources
+// the principal has edit permission on.
+func (c *Client) IterateEditMeetings(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return meetingResource.Iterate(ctx, c, permissionEdit, principal.ref(), opts...)
+}
+
+// CanViewMeeting checks if the principal has meeting#view permission.
+func (c *Client) CanViewMeeting(
+	ctx context.Context,
+	meetingId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return meetingResource.Can(ctx, c, meetingId, permissionView, principal.ref(), opts...)
+}
+
+// ExplainViewMeeting checks the permission like CanViewMeeting
+// and returns the trace explaining the result.
+func (c *Client) ExplainViewMeeting(
+	ctx context.Context,
+	meetingId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return meetingResource.Explain(ctx, c, meetingId, permissionView, principal.ref(), opts...)
+}
+
+// LookupViewMeetingMembers streams member subjects
+// which have meeting#view permission.
+func (c *Client) LookupViewMeetingMembers(
+	ctx context.Context,
+	meetingId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return meetingResource.LookupSubjects(ctx, c, meetingId, permissionView, definitionMember, fn, opts...)
+}
+
+// ListViewMeetings returns ids of meeting resources
+// the principal has view permission on.
+func (c *Client) ListViewMeetings(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return meetingResource.List(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// IterateViewMeetings iterates over ids of meeting resources
+// the principal has view permission on.
+func (c *Client) IterateViewMeetings(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return meetingResource.Iterate(ctx, c, permissionView, principal.ref(), opts...)
+}
+
+// CanDeleteMeeting checks if the principal has meeting#delete permission.
+func (c *Client) CanDeleteMeeting(
+	ctx context.Context,
+	meetingId string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return meetingResource.Can(ctx, c, meetingId, permissionDelete, principal.ref(), opts...)
+}
+
+// ExplainDeleteMeeting checks the permission like CanDeleteMeeting
+// and returns the trace explaining the result.
+func (c *Client) ExplainDeleteMeeting(
+	ctx context.Context,
+	meetingId string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return meetingResource.Explain(ctx, c, meetingId, permissionDelete, principal.ref(), opts...)
+}
+
+// LookupDeleteMeetingMembers streams member subjects
+// which have meeting#delete permission.
+func (c *Client) LookupDeleteMeetingMembers(
+	ctx context.Context,
+	meetingId string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return meetingResource.LookupSubjects(ctx, c, meetingId, permissionDelete, definitionMember, fn, opts...)
+}
+
+// ListDeleteMeetings returns ids of meeting resources
+// the principal has delete permission on.
+func (c *Client) ListDeleteMeetings(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return meetingResource.List(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// IterateDeleteMeetings iterates over ids of meeting resources
+// the principal has delete permission on.
+func (c *Client) IterateDeleteMeetings(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return meetingResource.Iterate(ctx, c, permissionDelete, principal.ref(), opts...)
+}
+
+// ListMeetings returns capabilities of meeting resources
+// the principal has access to, keyed by resource id.
+func (c *Client) ListMeetings(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) (map[string]*Meeting, error) {
+	return meetingResource.ListWithCapabilities(ctx, c, principal.ref(), opts...)
+}
+
+// schemaRelations are all relations of generated definitions,
+// split by the subject type.
+var schemaRelations = []schemaRelation{
+	{definition: definitionOrganization, relation: relationApiKey, subject: definitionApiKey},
+	{definition: definitionOrganization, relation: relationAdmin, subject: definitionMember},
+	{definition: definitionOrganization, relation: relationSDR, subject: definitionMember},
+	{definition: definitionTeam, relation: relationOrganization, subject: definitionOrganization},
+	{definition: definitionOffDay, relation: relationOrganization, subject: definitionOrganization},
+	{definition: definitionHoliday, relation: relationOrganization, subject: definitionOrganization},
+	{definition: definitionPassword, relation: relationOrganization, subject: definitionOrganization},
+	{definition: definitionContact, relation: relationOrganization, subject: definitionOrganization},
+	{definition: definitionContact, relation: relationOwner, subject: definitionMember},
+	{definition: definitionInbox, relation: relationOrganization, subject: definitionOrganization},
+	{definition: definitionInbox, relation: relationOwner, subject: definitionMember},
+	{definition: definitionSequence, relation: relationOrganization, subject: definitionOrganization},
+	{definition: definitionSequence, relation: relationOwner, subject: definitionMember},
+	{definition: definitionSequence, relation: relationSender, subject: definitionMember},
+	{definition: definitionSequence, relation: relationSender, subject: definitionTeam},
+	{definition: definitionSequence, relation: relationViewer, subject: definitionMember},
+	{definition: definitionSequence, relation: relationEditor, subject: definitionMember},
+	{definition: definitionSequence, relation: relationContact, subject: definitionContact},
+	{definition: definitionSequenceAction, relation: relationSequence, subject: definitionSequence},
+	{definition: definitionSequenceAction, relation: relationAssignee, subject: definitionMember},
+	{definition: definitionMeeting, relation: relationOrganization, subject: definitionOrganization},
+	{definition: definitionMeeting, relation: relationOwner, subject: definitionMember},
+}
+
+// organizationDependents are definitions owned by the organization,
+// they are deleted together with it.
+var organizationDependents = []*dependent{
+	{definition: definitionTeam, relation: relationOrganization},
+	{definition: definitionOffDay, relation: relationOrganization},
+	{definition: definitionHoliday, relation: relationOrganization},
+	{definition: definitionPassword, relation: relationOrganization},
+	{definition: definitionContact, relation: relationOrganization},
+	{definition: definitionInbox, relation: relationOrganization},
+	{definition: definitionSequence, relation: relationOrganization, dependents: sequenceDependents},
+	{definition: definitionMeeting, relation: relationOrganization},
+}
+
+// sequenceDependents are definitions owned by the sequence,
+// they are deleted together with it.
+var sequenceDependents = []*dependent{
+	{definition: definitionSequenceAction, relation: relationSequence},
+}
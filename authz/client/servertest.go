@@ -6,15 +6,42 @@ import (
 	"rift/authz/testauthz"
 )
 
-func StartTestServer(ctx context.Context) (*Client, error) {
-	authclient, err := testauthz.StartMemServer(ctx)
+// StartTestServer starts in-memory server with the latest schema.
+// Transport options, e.g. TLS, are ignored by the in-memory server.
+func StartTestServer(ctx context.Context, opts ...Option) (*Client, error) {
+	client, err := StartEmptyTestServer(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	client := &Client{c: authclient}
-	if err := client.MigrateSchema(ctx); err != nil {
+	schema, err := Schema(SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.WriteSchema(ctx, schema); err != nil {
 		return nil, err
 	}
 	return client, nil
 }
+
+// StartEmptyTestServer starts in-memory server without any schema.
+// Reads of the test client are fully consistent by default,
+// so tests don't depend on the cache.
+func StartEmptyTestServer(ctx context.Context, opts ...Option) (*Client, error) {
+	o, err := newOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		defaultConsistency: fullConsistency(),
+		retryPolicy:        o.retry,
+		checkCache:         o.newCheckCache(),
+	}
+	c.c, err = testauthz.StartMemServer(ctx, append(o.callOptions(), c.zedTokenDialOptions()...)...)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+// TraceResult is the result of a single step of the permission check.
+type TraceResult string
+
+const (
+	TraceHasPermission         TraceResult = "has permission"
+	TraceNoPermission          TraceResult = "no permission"
+	TraceConditionalPermission TraceResult = "conditional permission"
+)
+
+// Trace explains the permission check. It's a tree of permissions
+// and relations walked by spicedb from the resource to the subject,
+// e.g. offday#edit -> organization#admin, arrows are followed
+// to the relations of the related resources.
+type Trace struct {
+	// Resource is the checked object, e.g. offday:1.
+	Resource string
+	// Permission is the name of the permission or relation.
+	Permission string
+	// Relation is true if the step checks relation, not permission.
+	Relation bool
+	Result   TraceResult
+	// Caveat is set if the step evaluated caveat.
+	Caveat *TraceCaveat
+	// Cached is true if the result was served from the spicedb cache,
+	// then the subtree is not known.
+	Cached   bool
+	Children []*Trace
+}
+
+// TraceCaveat is the result of the caveat evaluation.
+type TraceCaveat struct {
+	Name       string
+	Expression string
+	// Result is one of true, false, missing context or unevaluated.
+	Result string
+	// MissingFields are names of the missing caveat context fields.
+	MissingFields []string
+}
+
+// newTrace converts the spicedb debug trace, it returns nil
+// if the trace is missing.
+func newTrace(t *pb.CheckDebugTrace) *Trace {
+	if t == nil {
+		return nil
+	}
+
+	trace := &Trace{
+		Resource:   t.GetResource().GetObjectType() + ":" + t.GetResource().GetObjectId(),
+		Permission: t.GetPermission(),
+		Relation:   t.GetPermissionType() == pb.CheckDebugTrace_PERMISSION_TYPE_RELATION,
+		Cached:     t.GetWasCachedResult(),
+	}
+
+	switch t.GetResult() {
+	case pb.CheckDebugTrace_PERMISSIONSHIP_HAS_PERMISSION:
+		trace.Result = TraceHasPermission
+	case pb.CheckDebugTrace_PERMISSIONSHIP_CONDITIONAL_PERMISSION:
+		trace.Result = TraceConditionalPermission
+	default:
+		trace.Result = TraceNoPermission
+	}
+
+	if info := t.GetCaveatEvaluationInfo(); info != nil {
+		trace.Caveat = &TraceCaveat{
+			Name:          info.GetCaveatName(),
+			Expression:    info.GetExpression(),
+			Result:        caveatResult(info.GetResult()),
+			MissingFields: info.GetPartialCaveatInfo().GetMissingRequiredContext(),
+		}
+
+		// spicedb reports caveated steps as conditional,
+		// even if the caveat has been evaluated
+		if trace.Result == TraceConditionalPermission {
+			switch info.GetResult() {
+			case pb.CaveatEvalInfo_RESULT_TRUE:
+				trace.Result = TraceHasPermission
+			case pb.CaveatEvalInfo_RESULT_FALSE:
+				trace.Result = TraceNoPermission
+			}
+		}
+	}
+
+	for _, sub := range t.GetSubProblems().GetTraces() {
+		trace.Children = append(trace.Children, newTrace(sub))
+	}
+	return trace
+}
+
+func caveatResult(r pb.CaveatEvalInfo_Result) string {
+	switch r {
+	case pb.CaveatEvalInfo_RESULT_TRUE:
+		return "true"
+	case pb.CaveatEvalInfo_RESULT_FALSE:
+		return "false"
+	case pb.CaveatEvalInfo_RESULT_MISSING_SOME_CONTEXT:
+		return "missing context"
+	default:
+		return "unevaluated"
+	}
+}
+
+// String returns the trace as an indented tree, one step per line, e.g.
+//
+//	offday:1#edit permission: has permission, caveat products: true
+//	  organization:rift#admin relation: has permission, caveat products: true
+//
+// A nil trace, e.g. from a server with tracing disabled, is empty.
+func (t *Trace) String() string {
+	if t == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	t.write(&b, 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (t *Trace) write(b *strings.Builder, depth int) {
+	if t == nil {
+		return
+	}
+
+	kind := "permission"
+	if t.Relation {
+		kind = "relation"
+	}
+
+	fmt.Fprintf(b, "%s%s#%s %s: %s", strings.Repeat("  ", depth), t.Resource, t.Permission, kind, t.Result)
+	if t.Caveat != nil {
+		fmt.Fprintf(b, ", caveat %s: %s", t.Caveat.Name, t.Caveat.Result)
+		if len(t.Caveat.MissingFields) > 0 {
+			fmt.Fprintf(b, " (%s)", strings.Join(t.Caveat.MissingFields, ", "))
+		}
+	}
+	if t.Cached {
+		b.WriteString(", cached")
+	}
+	b.WriteString("\n")
+
+	for _, child := range t.Children {
+		child.write(b, depth+1)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build authzdebug
+
+package client
+
+// debugTraces attaches traces of denied checks to ErrDenied.
+// It's enabled with the authzdebug build tag and must not be used
+// in production builds, because tracing slows down the checks.
+const debugTraces = true
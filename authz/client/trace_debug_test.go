@@ -0,0 +1,26 @@
+//go:build authzdebug
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"rift/assert"
+)
+
+func TestDebugTraces(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	err = tclient.CanEditOffDay(ctx, "offday", MemberPrincipal("bob"))
+
+	var denied *ErrDenied
+	assert.True(t, errors.As(err, &denied))
+	assert.True(t, denied.Trace != nil)
+	assert.ErrorContains(t, err, "offday:offday#edit permission: no permission")
+}
@@ -0,0 +1,7 @@
+//go:build !authzdebug
+
+package client
+
+// debugTraces attaches traces of denied checks to ErrDenied.
+// Build with the authzdebug tag to enable it.
+const debugTraces = false
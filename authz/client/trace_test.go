@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"rift/assert"
+)
+
+func TestExplain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	memberId := "alice"
+	offDayId := "offday"
+
+	_, err = tclient.WriteOrganizationAdmin(ctx, orgId, memberId)
+	assert.NoError(t, err)
+	_, err = tclient.WriteOffDayOrganization(ctx, offDayId, orgId)
+	assert.NoError(t, err)
+
+	t.Run("granted", func(t *testing.T) {
+		trace, err := tclient.ExplainEditOffDay(ctx, offDayId, MemberPrincipal(memberId))
+		assert.NoError(t, err)
+		assert.Equal(t, trace.String(), ""+
+			"offday:offday#edit permission: has permission, caveat products: true\n"+
+			"  organization:rift#admin relation: has permission, caveat products: true",
+		)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		trace, err := tclient.ExplainEditOffDay(ctx, offDayId, MemberPrincipal("bob"))
+		assert.ErrorContains(t, err, &ErrDenied{})
+		assert.Equal(t, trace.String(), ""+
+			"offday:offday#edit permission: no permission\n"+
+			"  organization:rift#admin relation: no permission",
+		)
+
+		var denied *ErrDenied
+		assert.True(t, errors.As(err, &denied))
+		assert.Equal(t, denied.Trace, trace)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		// the server doesn't return the trace if tracing is disabled
+		var trace *Trace
+		assert.Equal(t, trace.String(), "")
+
+		trace = &Trace{Resource: "offday:offday", Permission: "edit", Result: TraceNoPermission}
+		trace.Children = append(trace.Children, nil)
+		assert.Equal(t, trace.String(), "offday:offday#edit permission: no permission")
+	})
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrPreconditionFailed is returned by Tx.Commit when a precondition
+// of the transaction doesn't hold or a created relationship already exists.
+// Nothing is written in that case.
+var ErrPreconditionFailed = errors.New("authz: precondition failed")
+
+// Tx collects relationship updates and preconditions
+// and writes them atomically in a single request.
+// Either all updates are written or none of them.
+//
+//	token, err := c.Tx().
+//		Touch(
+//			RelationSequenceOrganization(sequenceId, orgId),
+//			RelationSequenceOwner(sequenceId, memberId),
+//		).
+//		MustExist(FilterOrganizationAdmin(orgId)).
+//		Commit(ctx)
+type Tx struct {
+	c             *Client
+	updates       []*pb.RelationshipUpdate
+	preconditions []*pb.Precondition
+}
+
+// Tx starts a new transaction.
+func (c *Client) Tx() *Tx {
+	return &Tx{c: c}
+}
+
+// Touch writes the relationships, or updates them if they exist.
+func (tx *Tx) Touch(rels ...*pb.Relationship) *Tx {
+	return tx.update(pb.RelationshipUpdate_OPERATION_TOUCH, rels)
+}
+
+// Create writes the relationships. The transaction fails with
+// ErrPreconditionFailed if any of them already exists.
+func (tx *Tx) Create(rels ...*pb.Relationship) *Tx {
+	return tx.update(pb.RelationshipUpdate_OPERATION_CREATE, rels)
+}
+
+// Delete deletes the relationships. Missing relationships are ignored.
+func (tx *Tx) Delete(rels ...*pb.Relationship) *Tx {
+	return tx.update(pb.RelationshipUpdate_OPERATION_DELETE, rels)
+}
+
+// MustExist requires at least one relationship matching the filter,
+// e.g. FilterOrganizationAdmin(orgId) requires the organization to have an admin.
+func (tx *Tx) MustExist(filter *pb.RelationshipFilter) *Tx {
+	return tx.precondition(pb.Precondition_OPERATION_MUST_MATCH, filter)
+}
+
+// MustNotExist requires no relationship matching the filter,
+// e.g. ExactFilter(rel) requires the relationship to not exist.
+func (tx *Tx) MustNotExist(filter *pb.RelationshipFilter) *Tx {
+	return tx.precondition(pb.Precondition_OPERATION_MUST_NOT_MATCH, filter)
+}
+
+// Commit writes all updates in a single request
+// and returns the token of the write.
+// Preconditions are checked in the same request.
+func (tx *Tx) Commit(ctx context.Context) (*pb.ZedToken, error) {
+	token, err := tx.write(ctx)
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.FailedPrecondition, codes.AlreadyExists:
+		return nil, fmt.Errorf("authz: write relationships %s: %w: %w", tx, ErrPreconditionFailed, err)
+	default:
+		return nil, fmt.Errorf("authz: write relationships %s: %w", tx, err)
+	}
+
+	setZedToken(ctx, token)
+	return token, nil
+}
+
+// write writes the transaction, it's retried only if it's idempotent.
+func (tx *Tx) write(ctx context.Context) (*pb.ZedToken, error) {
+	req := &pb.WriteRelationshipsRequest{
+		Updates:               tx.updates,
+		OptionalPreconditions: tx.preconditions,
+	}
+
+	var resp *pb.WriteRelationshipsResponse
+	write := func() (err error) {
+		resp, err = tx.c.c.WriteRelationships(ctx, req)
+		return err
+	}
+
+	if !tx.idempotent() {
+		// a retry of a write which succeeded on the server
+		// would fail on its own precondition or created relationship
+		if err := write(); err != nil {
+			return nil, err
+		}
+		return resp.WrittenAt, nil
+	}
+
+	if err := tx.c.retry(ctx, write); err != nil {
+		return nil, err
+	}
+	return resp.WrittenAt, nil
+}
+
+// String lists the updates of the transaction, it's used in errors.
+func (tx *Tx) String() string {
+	s := "["
+	for i, u := range tx.updates {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%s %q", u.Operation, relstr(u.Relationship))
+	}
+	return s + "]"
+}
+
+// idempotent reports if the transaction may be retried,
+// i.e. it only touches and deletes relationships.
+func (tx *Tx) idempotent() bool {
+	if len(tx.preconditions) > 0 {
+		return false
+	}
+	for _, u := range tx.updates {
+		if u.Operation == pb.RelationshipUpdate_OPERATION_CREATE {
+			return false
+		}
+	}
+	return true
+}
+
+func (tx *Tx) update(op pb.RelationshipUpdate_Operation, rels []*pb.Relationship) *Tx {
+	for _, rel := range rels {
+		tx.updates = append(tx.updates, &pb.RelationshipUpdate{
+			Operation:    op,
+			Relationship: rel,
+		})
+	}
+	return tx
+}
+
+func (tx *Tx) precondition(op pb.Precondition_Operation, filter *pb.RelationshipFilter) *Tx {
+	tx.preconditions = append(tx.preconditions, &pb.Precondition{
+		Operation: op,
+		Filter:    filter,
+	})
+	return tx
+}
+
+// ExactFilter matches only the given relationship.
+func ExactFilter(rel *pb.Relationship) *pb.RelationshipFilter {
+	return &pb.RelationshipFilter{
+		ResourceType:       rel.Resource.ObjectType,
+		OptionalResourceId: rel.Resource.ObjectId,
+		OptionalRelation:   rel.Relation,
+		OptionalSubjectFilter: &pb.SubjectFilter{
+			SubjectType:       rel.Subject.Object.ObjectType,
+			OptionalSubjectId: rel.Subject.Object.ObjectId,
+			OptionalRelation: &pb.SubjectFilter_RelationFilter{
+				Relation: rel.Subject.OptionalRelation,
+			},
+		},
+	}
+}
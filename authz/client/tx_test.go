@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"rift/assert"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"google.golang.org/grpc/codes"
+)
+
+func TestTx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	orgId := "rift"
+	adminId := "alice"
+	ownerId := "bob"
+	contactId := "contact"
+
+	readSequence := func(t *testing.T, sequenceId string) []*pb.Relationship {
+		rels, err := tclient.ReadRelationships(ctx, &pb.RelationshipFilter{
+			ResourceType:       definitionSequence,
+			OptionalResourceId: sequenceId,
+		})
+		assert.NoError(t, err)
+		return rels
+	}
+
+	createSequence := func(sequenceId string) *Tx {
+		return tclient.Tx().
+			Touch(
+				RelationSequenceOrganization(sequenceId, orgId),
+				RelationSequenceOwner(sequenceId, ownerId),
+				RelationSequenceContact(sequenceId, contactId),
+			).
+			MustExist(FilterOrganizationAdmin(orgId))
+	}
+
+	t.Run("precondition_failed", func(t *testing.T) {
+		_, err := createSequence("sequence").Commit(ctx)
+		assert.True(t, errors.Is(err, ErrPreconditionFailed))
+		assert.Len(t, readSequence(t, "sequence"), 0)
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		_, err := tclient.WriteOrganizationAdmin(ctx, orgId, adminId)
+		assert.NoError(t, err)
+
+		_, err = tclient.WriteOrganizationSDR(ctx, orgId, ownerId, ProductSequences)
+		assert.NoError(t, err)
+
+		token, err := createSequence("sequence").Commit(ctx)
+		assert.NoError(t, err)
+		assert.True(t, token.GetToken() != "")
+		assert.Len(t, readSequence(t, "sequence"), 3)
+
+		err = tclient.CanViewSequence(ctx, "sequence", MemberPrincipal(ownerId), AtLeastAsFresh(token))
+		assert.NoError(t, err)
+	})
+
+	t.Run("must_not_exist", func(t *testing.T) {
+		owner := RelationSequenceOwner("sequence", ownerId)
+		_, err := tclient.Tx().
+			Touch(RelationSequenceViewer("sequence", "carol")).
+			MustNotExist(ExactFilter(owner)).
+			Commit(ctx)
+		assert.True(t, errors.Is(err, ErrPreconditionFailed))
+		assert.Len(t, readSequence(t, "sequence"), 3)
+	})
+
+	t.Run("create_exists", func(t *testing.T) {
+		_, err := tclient.Tx().
+			Create(
+				RelationSequenceViewer("sequence", "carol"),
+				RelationSequenceOwner("sequence", ownerId),
+			).
+			Commit(ctx)
+		assert.True(t, errors.Is(err, ErrPreconditionFailed))
+		assert.Len(t, readSequence(t, "sequence"), 3)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := tclient.Tx().
+			Delete(
+				RelationSequenceOrganization("sequence", orgId),
+				RelationSequenceOwner("sequence", ownerId),
+				RelationSequenceContact("sequence", contactId),
+			).
+			Commit(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, readSequence(t, "sequence"), 0)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		policy := DefaultRetryPolicy()
+		policy.InitialInterval = time.Millisecond
+		policy.MaxInterval = time.Millisecond
+
+		const writeMethod = "/authzed.api.v1.PermissionsService/WriteRelationships"
+
+		f := &failer{method: writeMethod, code: codes.Unavailable, failures: 1}
+		tclient, err := StartTestServer(ctx, WithRetry(policy), f.options())
+		assert.NoError(t, err)
+
+		_, err = tclient.Tx().Touch(RelationOffDayOrganization("offday", orgId)).Commit(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, f.attempts, 2)
+
+		// transactions with preconditions are not retried
+		f.attempts = 0
+		_, err = tclient.Tx().
+			Touch(RelationOffDayOrganization("offday", orgId)).
+			MustExist(FilterOffDayOrganization("offday")).
+			Commit(ctx)
+		assert.True(t, err != nil)
+		assert.Equal(t, f.attempts, 1)
+	})
+}
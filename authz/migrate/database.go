@@ -0,0 +1,55 @@
+package migrate
+
+import (
+	"context"
+
+	"rift/authz/client"
+	"rift/memdb"
+)
+
+// Database interface provides methods to record the applied schema version.
+type Database interface {
+	SchemaVersion(ctx context.Context) (int, error)
+	SetSchemaVersion(ctx context.Context, version int) error
+}
+
+type DB struct {
+	db *memdb.DB
+}
+
+func NewDB(db *memdb.DB) *DB {
+	return &DB{db: db}
+}
+
+func (pd *DB) SchemaVersion(ctx context.Context) (int, error) {
+	return pd.db.AuthzSchemaVersion(), nil
+}
+
+func (pd *DB) SetSchemaVersion(ctx context.Context, version int) error {
+	pd.db.SetAuthzSchemaVersion(version)
+	return nil
+}
+
+// SchemaDB reads the applied schema version from the mark of the schema
+// written by the Migrator, so the version is kept by SpiceDB together
+// with the schema, across restarts and instances.
+type SchemaDB struct {
+	c *client.Client
+}
+
+func NewSchemaDB(c *client.Client) *SchemaDB {
+	return &SchemaDB{c: c}
+}
+
+func (sd *SchemaDB) SchemaVersion(ctx context.Context) (int, error) {
+	schema, err := sd.c.ReadSchema(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return schemaVersion(schema)
+}
+
+// SetSchemaVersion does nothing, the version is written with the schema.
+func (sd *SchemaDB) SetSchemaVersion(ctx context.Context, version int) error {
+	return nil
+}
@@ -0,0 +1,159 @@
+package migrate
+
+import (
+	"fmt"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	nsdiff "github.com/authzed/spicedb/pkg/diff/namespace"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// Change is a single definition change between two schemas.
+type Change struct {
+	Type       nsdiff.DeltaType
+	Definition string
+	// Relation is the relation or permission name,
+	// empty if the whole definition is added or removed.
+	Relation string
+	// SubjectType is the added or removed subject type of the relation.
+	SubjectType *core.AllowedRelation
+}
+
+func (c Change) String() string {
+	s := c.Definition
+	if c.Relation != "" {
+		s += "#" + c.Relation
+	}
+	if c.SubjectType != nil {
+		s += "@" + subjectTypeString(c.SubjectType)
+	}
+	return string(c.Type) + " " + s
+}
+
+// Destructive reports if the change removes relationships
+// which may still exist.
+func (c Change) Destructive() bool {
+	return c.filter() != nil
+}
+
+// filter returns relationships filter removed by the change,
+// or nil if the change doesn't remove any relationships.
+func (c Change) filter() *pb.RelationshipFilter {
+	switch c.Type {
+	case nsdiff.NamespaceRemoved:
+		return &pb.RelationshipFilter{
+			ResourceType: c.Definition,
+		}
+	case nsdiff.RemovedRelation:
+		return &pb.RelationshipFilter{
+			ResourceType:     c.Definition,
+			OptionalRelation: c.Relation,
+		}
+	case nsdiff.RelationAllowedTypeRemoved:
+		subject := &pb.SubjectFilter{
+			SubjectType: c.SubjectType.Namespace,
+		}
+		if rel := c.SubjectType.GetRelation(); rel != "" && rel != tuple.Ellipsis {
+			subject.OptionalRelation = &pb.SubjectFilter_RelationFilter{Relation: rel}
+		}
+		return &pb.RelationshipFilter{
+			ResourceType:          c.Definition,
+			OptionalRelation:      c.Relation,
+			OptionalSubjectFilter: subject,
+		}
+	default:
+		return nil
+	}
+}
+
+// Diff compares definitions of the current and the target schema.
+// An empty current schema is treated as a schema without definitions.
+func Diff(current, target string) ([]Change, error) {
+	currentDefs, err := compileDefinitions("current", current)
+	if err != nil {
+		return nil, err
+	}
+
+	targetDefs, err := compileDefinitions("target", target)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, nd := range currentDefs {
+		names = append(names, nd.Name)
+	}
+	for _, nd := range targetDefs {
+		if findDefinition(currentDefs, nd.Name) == nil {
+			names = append(names, nd.Name)
+		}
+	}
+
+	var changes []Change
+	for _, name := range names {
+		diff, err := nsdiff.DiffNamespaces(findDefinition(currentDefs, name), findDefinition(targetDefs, name))
+		if err != nil {
+			return nil, fmt.Errorf("diff definition %s: %w", name, err)
+		}
+
+		for _, delta := range diff.Deltas() {
+			changes = append(changes, Change{
+				Type:        delta.Type,
+				Definition:  name,
+				Relation:    delta.RelationName,
+				SubjectType: delta.AllowedType,
+			})
+		}
+	}
+	return changes, nil
+}
+
+func compileDefinitions(name, schema string) ([]*core.NamespaceDefinition, error) {
+	compiled, err := compileSchema(name, schema)
+	if err != nil || compiled == nil {
+		return nil, err
+	}
+	return compiled.ObjectDefinitions, nil
+}
+
+// compileSchema compiles the schema, it returns nil for an empty schema.
+func compileSchema(name, schema string) (*compiler.CompiledSchema, error) {
+	if schema == "" {
+		return nil, nil
+	}
+
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source(name),
+		SchemaString: schema,
+	}, compiler.AllowUnprefixedObjectType())
+	if err != nil {
+		return nil, fmt.Errorf("compile %s schema: %w", name, err)
+	}
+	return compiled, nil
+}
+
+func findDefinition(defs []*core.NamespaceDefinition, name string) *core.NamespaceDefinition {
+	for _, nd := range defs {
+		if nd.Name == name {
+			return nd
+		}
+	}
+	return nil
+}
+
+func subjectTypeString(allowed *core.AllowedRelation) string {
+	s := allowed.Namespace
+	switch {
+	case allowed.GetPublicWildcard() != nil:
+		s += ":*"
+	case allowed.GetRelation() != tuple.Ellipsis:
+		s += "#" + allowed.GetRelation()
+	}
+	if allowed.RequiredCaveat != nil {
+		s += " with " + allowed.RequiredCaveat.CaveatName
+	}
+	return s
+}
@@ -0,0 +1,159 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rift/authz/client"
+	"rift/authz/syncer"
+)
+
+// LockKey is the key of the migration lock, see syncer.NewRedisMutex.
+// It differs from the syncer one, so migrations don't wait for the syncer.
+const LockKey = "authz_migrate"
+
+// lockRetryInterval is the delay between attempts to lock the mutex
+// locked by other instance.
+const lockRetryInterval = time.Second
+
+// Migration migrates the authz schema to the given version.
+type Migration struct {
+	// Version of the schema, versions start at 1 and are consecutive.
+	Version int
+
+	// Schema is the target schema of the migration.
+	Schema string
+
+	// Data migrates relationships. It runs with the intermediate schema,
+	// which has relations of both the current and the target schema,
+	// so it can move relationships of removed relations to added ones.
+	// It's required if the schema change is destructive, i.e. removes
+	// definitions, relations or subject types which still have relationships.
+	Data func(ctx context.Context, c *client.Client) error
+}
+
+// Migrator applies schema migrations which have not been applied yet.
+type Migrator struct {
+	c          *client.Client
+	db         Database
+	mx         syncer.Mutex
+	migrations []Migration
+}
+
+func New(c *client.Client, db Database, mx syncer.Mutex, migrations ...Migration) (*Migrator, error) {
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("migration %d: expected version %d", m.Version, i+1)
+		}
+		if m.Schema == "" {
+			return nil, fmt.Errorf("migration %d: empty schema", m.Version)
+		}
+	}
+
+	return &Migrator{
+		c:          c,
+		db:         db,
+		mx:         mx,
+		migrations: migrations,
+	}, nil
+}
+
+// Migrate applies all pending migrations in order.
+// Migrations are serialized across instances with the mutex,
+// so it waits until other instance finishes migrating or ctx is done.
+func (m *Migrator) Migrate(ctx context.Context) error {
+	if err := m.lock(ctx); err != nil {
+		return fmt.Errorf("failed to lock: %w", err)
+	}
+	defer m.mx.Unlock(ctx)
+
+	applied, err := m.db.SchemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	// NOTE: when applied version is newer than the latest migration,
+	// then the migration has been done by a newer instance, and
+	// there's nothing to do.
+	for _, migration := range m.migrations {
+		if migration.Version <= applied {
+			continue
+		}
+
+		if err := m.migrate(ctx, migration); err != nil {
+			return fmt.Errorf("failed to migrate to version %d: %w", migration.Version, err)
+		}
+
+		if err := m.db.SetSchemaVersion(ctx, migration.Version); err != nil {
+			return fmt.Errorf("failed to set schema version %d: %w", migration.Version, err)
+		}
+	}
+	return nil
+}
+
+// lock locks the mutex, it retries while the mutex
+// is locked by other instance until ctx is done.
+func (m *Migrator) lock(ctx context.Context) error {
+	for {
+		err := m.mx.Lock(ctx)
+		if err == nil || !syncer.IsMutexLocked(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", err, ctx.Err())
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// migrate writes the intermediate schema first, so the data migration can
+// write relationships of added relations and read the removed ones. The target
+// schema is written once the destructive changes have no relationships left.
+// Both schemas are marked with the version, see SchemaDB.
+func (m *Migrator) migrate(ctx context.Context, migration Migration) error {
+	current, err := m.c.ReadSchema(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes, err := Diff(current, migration.Schema)
+	if err != nil {
+		return err
+	}
+
+	intermediate, err := intermediateSchema(current, migration.Schema)
+	if err != nil {
+		return err
+	}
+	if err := m.c.WriteSchema(ctx, stampSchema(intermediate, migration.Version-1)); err != nil {
+		return fmt.Errorf("intermediate schema: %w", err)
+	}
+
+	if migration.Data != nil {
+		if err := migration.Data(ctx, m.c); err != nil {
+			return fmt.Errorf("data migration: %w", err)
+		}
+	}
+
+	// the data migration is expected to remove relationships
+	// of the destructive changes, so they are checked after it
+	for _, change := range changes {
+		filter := change.filter()
+		if filter == nil {
+			continue
+		}
+
+		found, err := m.c.HasRelationships(ctx, filter, client.FullyConsistent())
+		if err != nil {
+			return err
+		}
+		if found {
+			return fmt.Errorf("destructive change %s: relationships still exist", change)
+		}
+	}
+
+	return m.c.WriteSchema(ctx, stampSchema(migration.Schema, migration.Version))
+}
@@ -0,0 +1,366 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"rift/assert"
+	"rift/authz/client"
+	"rift/authz/syncer"
+	"rift/memdb"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	nsdiff "github.com/authzed/spicedb/pkg/diff/namespace"
+	"github.com/authzed/spicedb/pkg/tuple"
+	"github.com/go-redsync/redsync/v4"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testSchemaV1 = `
+definition member {}
+
+definition document {
+    relation viewer: member
+    relation editor: member
+
+    permission view = viewer + editor
+}`
+
+	testSchemaV2 = `
+definition member {}
+
+definition document {
+    relation viewer: member
+
+    permission view = viewer
+}`
+
+	// editor is renamed to writer
+	testSchemaRenamed = `
+definition member {}
+
+definition document {
+    relation viewer: member
+    relation writer: member
+
+    permission view = viewer + writer
+}`
+)
+
+func TestMigrate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("migrations", func(t *testing.T) {
+		tclient, err := client.StartEmptyTestServer(ctx)
+		assert.NoError(t, err)
+
+		migrations, err := Migrations()
+		assert.NoError(t, err)
+		assert.Len(t, migrations, client.SchemaVersion)
+
+		db := NewDB(memdb.New())
+		m, err := New(tclient, db, syncer.NewLocalMutex(), migrations...)
+		assert.NoError(t, err)
+
+		// run twice to check that applied migrations are skipped
+		for i := 0; i < 2; i++ {
+			err = m.Migrate(ctx)
+			assert.NoError(t, err)
+
+			version, err := db.SchemaVersion(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, version, client.SchemaVersion)
+		}
+
+		schema, err := tclient.ReadSchema(ctx)
+		assert.NoError(t, err)
+
+		changes, err := Diff(schema, migrations[len(migrations)-1].Schema)
+		assert.NoError(t, err)
+		for _, change := range changes {
+			// the version mark is a comment
+			assert.Equal(t, change.Type, nsdiff.NamespaceCommentsChanged)
+		}
+
+		// the version is kept in the schema
+		version, err := NewSchemaDB(tclient).SchemaVersion(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, version, client.SchemaVersion)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		tclient, err := client.StartEmptyTestServer(ctx)
+		assert.NoError(t, err)
+
+		db := NewSchemaDB(tclient)
+		mx := syncer.NewLocalMutex()
+
+		m, err := New(tclient, db, mx, Migration{Version: 1, Schema: testSchemaV1})
+		assert.NoError(t, err)
+		assert.NoError(t, m.Migrate(ctx))
+
+		_, err = tclient.Tx().Touch(tuple.ParseRel("document:doc#editor@member:alice")).Commit(ctx)
+		assert.NoError(t, err)
+
+		failed := errors.New("failed")
+		rename := func(ctx context.Context, c *client.Client) error {
+			editors, err := c.ReadRelationships(ctx, &pb.RelationshipFilter{
+				ResourceType:     "document",
+				OptionalRelation: "editor",
+			})
+			if err != nil {
+				return err
+			}
+
+			tx := c.Tx()
+			for _, editor := range editors {
+				writer := proto.Clone(editor).(*pb.Relationship)
+				writer.Relation = "writer"
+				tx.Delete(editor).Touch(writer)
+			}
+			if _, err := tx.Commit(ctx); err != nil {
+				return err
+			}
+			return failed
+		}
+
+		// writers are written with the intermediate schema,
+		// the migration fails before the target schema is written
+		m, err = New(tclient, db, mx,
+			Migration{Version: 1, Schema: testSchemaV1},
+			Migration{Version: 2, Schema: testSchemaRenamed, Data: rename},
+		)
+		assert.NoError(t, err)
+
+		err = m.Migrate(ctx)
+		assert.True(t, errors.Is(err, failed))
+
+		version, err := db.SchemaVersion(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, version, 1)
+
+		schema, err := tclient.ReadSchema(ctx)
+		assert.NoError(t, err)
+		assert.True(t, strings.Contains(schema, "relation editor"))
+		assert.True(t, strings.Contains(schema, "relation writer"))
+
+		// the migration is retried by a restarted instance
+		failed = nil
+		m, err = New(tclient, NewSchemaDB(tclient), mx,
+			Migration{Version: 1, Schema: testSchemaV1},
+			Migration{Version: 2, Schema: testSchemaRenamed, Data: rename},
+		)
+		assert.NoError(t, err)
+		assert.NoError(t, m.Migrate(ctx))
+
+		version, err = db.SchemaVersion(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, version, 2)
+
+		schema, err = tclient.ReadSchema(ctx)
+		assert.NoError(t, err)
+		assert.True(t, !strings.Contains(schema, "relation editor"))
+
+		rels, err := tclient.ReadRelationships(ctx, &pb.RelationshipFilter{ResourceType: "document"})
+		assert.NoError(t, err)
+		assert.Len(t, rels, 1)
+		assert.Equal(t, tuple.MustStringRelationship(rels[0]), "document:doc#writer@member:alice")
+	})
+
+	t.Run("destructive", func(t *testing.T) {
+		tclient, err := client.StartEmptyTestServer(ctx)
+		assert.NoError(t, err)
+
+		db := NewDB(memdb.New())
+		mx := syncer.NewLocalMutex()
+
+		m, err := New(tclient, db, mx, Migration{Version: 1, Schema: testSchemaV1})
+		assert.NoError(t, err)
+		assert.NoError(t, m.Migrate(ctx))
+
+		editor := tuple.MustParse("document:doc#editor@member:alice")
+		_, err = tclient.UNSAFE_GetClient().WriteRelationships(ctx, &pb.WriteRelationshipsRequest{
+			Updates: []*pb.RelationshipUpdate{tuple.UpdateToRelationshipUpdate(tuple.Touch(editor))},
+		})
+		assert.NoError(t, err)
+
+		// editor relationships still exist
+		m, err = New(tclient, db, mx,
+			Migration{Version: 1, Schema: testSchemaV1},
+			Migration{Version: 2, Schema: testSchemaV2},
+		)
+		assert.NoError(t, err)
+
+		err = m.Migrate(ctx)
+		assert.ErrorContains(t, err, "destructive change removed-relation document#editor")
+
+		version, err := db.SchemaVersion(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, version, 1)
+
+		// editors become viewers
+		m, err = New(tclient, db, mx,
+			Migration{Version: 1, Schema: testSchemaV1},
+			Migration{
+				Version: 2,
+				Schema:  testSchemaV2,
+				Data: func(ctx context.Context, c *client.Client) error {
+					viewer := tuple.MustParse("document:doc#viewer@member:alice")
+					_, err := c.UNSAFE_GetClient().WriteRelationships(ctx, &pb.WriteRelationshipsRequest{
+						Updates: []*pb.RelationshipUpdate{
+							tuple.UpdateToRelationshipUpdate(tuple.Delete(editor)),
+							tuple.UpdateToRelationshipUpdate(tuple.Touch(viewer)),
+						},
+					})
+					return err
+				},
+			},
+		)
+		assert.NoError(t, err)
+		assert.NoError(t, m.Migrate(ctx))
+
+		version, err = db.SchemaVersion(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, version, 2)
+
+		rels, err := tclient.ReadRelationships(ctx, &pb.RelationshipFilter{ResourceType: "document"})
+		assert.NoError(t, err)
+		assert.Len(t, rels, 1)
+		assert.Equal(t, tuple.MustStringRelationship(rels[0]), "document:doc#viewer@member:alice")
+	})
+
+	t.Run("locked", func(t *testing.T) {
+		tclient, err := client.StartEmptyTestServer(ctx)
+		assert.NoError(t, err)
+
+		mx := syncer.NewLocalMutex()
+		assert.NoError(t, mx.Lock(ctx))
+		defer mx.Unlock(ctx)
+
+		m, err := New(tclient, NewDB(memdb.New()), mx, Migration{Version: 1, Schema: testSchemaV1})
+		assert.NoError(t, err)
+
+		tctx, tcancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer tcancel()
+
+		err = m.Migrate(tctx)
+		assert.ErrorContains(t, err, "failed to lock")
+
+		schema, err := tclient.ReadSchema(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, schema, "")
+	})
+
+	t.Run("wait", func(t *testing.T) {
+		tclient, err := client.StartEmptyTestServer(ctx)
+		assert.NoError(t, err)
+
+		// other instance holds the lock for a while
+		mx := &busyMutex{Mutex: syncer.NewLocalMutex(), busy: 1}
+
+		db := NewDB(memdb.New())
+		m, err := New(tclient, db, mx, Migration{Version: 1, Schema: testSchemaV1})
+		assert.NoError(t, err)
+		assert.NoError(t, m.Migrate(ctx))
+		assert.Equal(t, mx.busy, 0)
+
+		version, err := db.SchemaVersion(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, version, 1)
+	})
+
+	t.Run("versions", func(t *testing.T) {
+		_, err := New(nil, nil, nil, Migration{Version: 2, Schema: testSchemaV2})
+		assert.ErrorContains(t, err, "migration 2: expected version 1")
+
+		_, err = New(nil, nil, nil, Migration{Version: 1})
+		assert.ErrorContains(t, err, "migration 1: empty schema")
+	})
+}
+
+func TestDiff(t *testing.T) {
+	changes, err := Diff(testSchemaV1, testSchemaV2)
+	assert.NoError(t, err)
+
+	var destructive []string
+	for _, change := range changes {
+		if change.Destructive() {
+			destructive = append(destructive, change.String())
+		}
+	}
+	assert.Equal(t, destructive, []string{"removed-relation document#editor"})
+
+	changes, err = Diff("", testSchemaV1)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, changes[0].Type, nsdiff.NamespaceAdded)
+
+	changes, err = Diff(testSchemaV1, "definition member {}")
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, changes[0].String(), "namespace-removed document")
+	assert.True(t, changes[0].Destructive())
+}
+
+func TestIntermediateSchema(t *testing.T) {
+	intermediate, err := intermediateSchema(testSchemaV1, testSchemaRenamed)
+	assert.NoError(t, err)
+
+	// nothing is removed from the current schema
+	changes, err := Diff(testSchemaV1, intermediate)
+	assert.NoError(t, err)
+	var strs []string
+	for _, change := range changes {
+		strs = append(strs, change.String())
+	}
+	assert.Equal(t, strs, []string{
+		"added-relation document#writer",
+		"changed-permission-implementation document#view",
+	})
+
+	// the target schema removes only the renamed relation
+	changes, err = Diff(intermediate, testSchemaRenamed)
+	assert.NoError(t, err)
+	strs = nil
+	for _, change := range changes {
+		strs = append(strs, change.String())
+	}
+	assert.Equal(t, strs, []string{"removed-relation document#editor"})
+
+	intermediate, err = intermediateSchema("", testSchemaV1)
+	assert.NoError(t, err)
+	assert.Equal(t, intermediate, testSchemaV1)
+}
+
+func TestSchemaVersion(t *testing.T) {
+	version, err := schemaVersion(testSchemaV1)
+	assert.NoError(t, err)
+	assert.Equal(t, version, 0)
+
+	schema := stampSchema(stampSchema(testSchemaV1, 1), 2)
+	assert.Equal(t, strings.Count(schema, versionComment), 1)
+
+	version, err = schemaVersion(schema)
+	assert.NoError(t, err)
+	assert.Equal(t, version, 2)
+}
+
+// busyMutex fails to lock like a redis mutex locked by other instance,
+// until busy attempts fail.
+type busyMutex struct {
+	syncer.Mutex
+	busy int
+}
+
+func (m *busyMutex) Lock(ctx context.Context) error {
+	if m.busy > 0 {
+		m.busy--
+		return redsync.ErrFailed
+	}
+	return m.Mutex.Lock(ctx)
+}
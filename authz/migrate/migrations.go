@@ -0,0 +1,34 @@
+package migrate
+
+import (
+	"fmt"
+
+	"rift/authz/client"
+)
+
+// migrations lists schema migrations in order, their schemas are
+// read from authz/client/schemas. When a new schema version is added,
+// add its migration here, together with the data step
+// if the schema change is destructive.
+var migrations = []Migration{
+	{Version: 1},
+	{Version: 2},
+}
+
+// Migrations returns all schema migrations in order.
+func Migrations() ([]Migration, error) {
+	if len(migrations) != client.SchemaVersion {
+		return nil, fmt.Errorf("expected %d migrations, got %d", client.SchemaVersion, len(migrations))
+	}
+
+	out := make([]Migration, len(migrations))
+	for i, m := range migrations {
+		schema, err := client.Schema(m.Version)
+		if err != nil {
+			return nil, err
+		}
+		m.Schema = schema
+		out[i] = m
+	}
+	return out, nil
+}
@@ -0,0 +1,128 @@
+package migrate
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/generator"
+	"google.golang.org/protobuf/proto"
+)
+
+// versionComment marks the schema with the migration version it's written by.
+// SpiceDB keeps it as a comment of the first definition.
+const versionComment = "// authz schema version: "
+
+var versionCommentRe = regexp.MustCompile(`(?m)^[ \t]*` + versionComment + `(\d+)[ \t]*\n`)
+
+// stampSchema marks the schema with the version, replacing any previous mark.
+func stampSchema(schema string, version int) string {
+	return versionComment + strconv.Itoa(version) + "\n" + versionCommentRe.ReplaceAllString(schema, "")
+}
+
+// schemaVersion returns the version the schema is marked with,
+// zero if the schema is not marked.
+func schemaVersion(schema string) (int, error) {
+	m := versionCommentRe.FindStringSubmatch(schema)
+	if m == nil {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid schema version %q: %w", m[1], err)
+	}
+	return version, nil
+}
+
+// intermediateSchema returns the current schema extended with the additions
+// of the target schema, so a data migration can write relationships of new
+// relations while it still reads the removed ones:
+//   - definitions and caveats of both schemas are kept, target caveats win;
+//   - relations of both schemas are kept, with subject types of both;
+//   - permissions are taken from the target, removed ones are kept,
+//     since remaining permissions of the current schema may refer to them.
+func intermediateSchema(current, target string) (string, error) {
+	currentSchema, err := compileSchema("current", current)
+	if err != nil {
+		return "", err
+	}
+	if currentSchema == nil {
+		return target, nil
+	}
+
+	targetSchema, err := compileSchema("target", target)
+	if err != nil {
+		return "", err
+	}
+
+	var defs []compiler.SchemaDefinition
+	for _, caveat := range targetSchema.CaveatDefinitions {
+		defs = append(defs, caveat)
+	}
+	for _, caveat := range currentSchema.CaveatDefinitions {
+		if !slices.ContainsFunc(targetSchema.CaveatDefinitions, func(c *core.CaveatDefinition) bool {
+			return c.Name == caveat.Name
+		}) {
+			defs = append(defs, caveat)
+		}
+	}
+
+	for _, nd := range currentSchema.ObjectDefinitions {
+		defs = append(defs, mergeDefinition(nd, findDefinition(targetSchema.ObjectDefinitions, nd.Name)))
+	}
+	for _, nd := range targetSchema.ObjectDefinitions {
+		if findDefinition(currentSchema.ObjectDefinitions, nd.Name) == nil {
+			defs = append(defs, nd)
+		}
+	}
+
+	schema, _, err := generator.GenerateSchema(defs)
+	if err != nil {
+		return "", fmt.Errorf("generate intermediate schema: %w", err)
+	}
+	return schema, nil
+}
+
+// mergeDefinition merges relations and permissions of the target definition
+// into the current one, see intermediateSchema.
+func mergeDefinition(current, target *core.NamespaceDefinition) *core.NamespaceDefinition {
+	if target == nil {
+		return current
+	}
+
+	merged := proto.Clone(current).(*core.NamespaceDefinition)
+	for _, rel := range target.Relation {
+		i := slices.IndexFunc(merged.Relation, func(r *core.Relation) bool {
+			return r.Name == rel.Name
+		})
+
+		switch {
+		case i < 0:
+			merged.Relation = append(merged.Relation, rel)
+		case isPermission(merged.Relation[i]):
+			// permission is changed or replaced by a relation
+			merged.Relation[i] = rel
+		case !isPermission(rel):
+			allowed := merged.Relation[i].TypeInformation.AllowedDirectRelations
+			for _, subjectType := range rel.TypeInformation.AllowedDirectRelations {
+				if !slices.ContainsFunc(allowed, func(a *core.AllowedRelation) bool {
+					return subjectTypeString(a) == subjectTypeString(subjectType)
+				}) {
+					allowed = append(allowed, subjectType)
+				}
+			}
+			merged.Relation[i].TypeInformation.AllowedDirectRelations = allowed
+		}
+		// NOTE: a relation replaced by a permission is kept,
+		// so the data migration can still move its relationships.
+	}
+	return merged
+}
+
+func isPermission(rel *core.Relation) bool {
+	return rel.UsersetRewrite != nil
+}
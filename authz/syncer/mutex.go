@@ -16,10 +16,15 @@ type RedisMutes struct {
 	lock *redsync.Mutex
 }
 
-func NewRedisMutex(redc *redsync.Redsync) *RedisMutes {
-	const mutexLockKey = "authz_syncer"
+// LockKey is the key of the syncer lock, see NewRedisMutex.
+const LockKey = "authz_syncer"
+
+// NewRedisMutex creates a mutex shared by all instances through redis.
+// Different jobs use different keys, e.g. LockKey for the syncer,
+// so they don't block each other.
+func NewRedisMutex(redc *redsync.Redsync, key string) *RedisMutes {
 	return &RedisMutes{
-		lock: redc.NewMutex(mutexLockKey),
+		lock: redc.NewMutex(key),
 	}
 }
 
@@ -31,6 +36,38 @@ func (r *RedisMutes) Unlock(ctx context.Context) {
 	_, _ = r.lock.UnlockContext(ctx)
 }
 
-func isMutexLocked(err error) bool {
-	return errors.Is(err, redsync.ErrFailed)
+// LocalMutex is a mutex which serializes only within a single process.
+// It's meant for local development and tests, where there's
+// a single instance running.
+type LocalMutex struct {
+	ch chan struct{}
+}
+
+func NewLocalMutex() *LocalMutex {
+	return &LocalMutex{
+		ch: make(chan struct{}, 1),
+	}
+}
+
+func (l *LocalMutex) Lock(ctx context.Context) error {
+	select {
+	case l.ch <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *LocalMutex) Unlock(ctx context.Context) {
+	select {
+	case <-l.ch:
+	default:
+	}
+}
+
+// IsMutexLocked reports if the lock failed, because the mutex
+// is locked by other instance.
+func IsMutexLocked(err error) bool {
+	var taken *redsync.ErrTaken
+	return errors.Is(err, redsync.ErrFailed) || errors.As(err, &taken)
 }
@@ -11,11 +11,10 @@ import (
 	"github.com/authzed/authzed-go/v1"
 )
 
-const batchSize = 1000
-
 type Syncer struct {
 	db     Database
 	mx     Mutex
+	c      *client.Client
 	authzC *authzed.ClientWithExperimental
 }
 
@@ -23,6 +22,7 @@ func New(db Database, mx Mutex, c *client.Client) (*Syncer, error) {
 	return &Syncer{
 		db:     db,
 		mx:     mx,
+		c:      c,
 		authzC: c.UNSAFE_GetClient(),
 	}, nil
 }
@@ -31,7 +31,7 @@ func New(db Database, mx Mutex, c *client.Client) (*Syncer, error) {
 func (s *Syncer) Sync(ctx context.Context) error {
 	if err := s.mx.Lock(ctx); err != nil {
 		// already locked by other instance
-		if isMutexLocked(err) {
+		if IsMutexLocked(err) {
 			return nil
 		}
 		return fmt.Errorf("failed to lock: %w", err)
@@ -64,7 +64,7 @@ func (s *Syncer) Sync(ctx context.Context) error {
 func (s *Syncer) Resync(ctx context.Context) error {
 	if err := s.mx.Lock(ctx); err != nil {
 		// already locked by other instance
-		if isMutexLocked(err) {
+		if IsMutexLocked(err) {
 			return nil
 		}
 		return fmt.Errorf("failed to lock: %w", err)
@@ -132,36 +132,31 @@ func (s *Syncer) syncMembers(ctx context.Context) error {
 		return fmt.Errorf("failed to query members: %w", err)
 	}
 
-	for i := 0; i < len(members); i += batchSize {
-		end := i + batchSize
-		if end > len(members) {
-			end = len(members)
-		}
-
-		req := &pb.WriteRelationshipsRequest{
-			Updates: make([]*pb.RelationshipUpdate, 0, batchSize),
+	updates := make([]*pb.RelationshipUpdate, 0, len(members))
+	for _, m := range members {
+		products := make([]client.Product, len(m.Products))
+		for i, p := range m.Products {
+			products[i] = client.Product(p)
 		}
 
-		for _, m := range members[i:end] {
-			switch {
-			case m.Role == memdb.RoleAdmin:
-				req.Updates = append(req.Updates, &pb.RelationshipUpdate{
-					Operation:    pb.RelationshipUpdate_OPERATION_TOUCH,
-					Relationship: client.RelationOrganizationAdmin(m.OrganizationID, m.ID),
-				})
-			case m.Role == memdb.RoleSDR:
-				req.Updates = append(req.Updates, &pb.RelationshipUpdate{
-					Operation:    pb.RelationshipUpdate_OPERATION_TOUCH,
-					Relationship: client.RelationOrganizationSDR(m.OrganizationID, m.ID),
-				})
-			default:
-				return fmt.Errorf("unknown role: %s", m.Role)
-			}
+		switch {
+		case m.Role == memdb.RoleAdmin:
+			updates = append(updates, &pb.RelationshipUpdate{
+				Operation:    pb.RelationshipUpdate_OPERATION_TOUCH,
+				Relationship: client.RelationOrganizationAdmin(m.OrganizationID, m.ID, products...),
+			})
+		case m.Role == memdb.RoleSDR:
+			updates = append(updates, &pb.RelationshipUpdate{
+				Operation:    pb.RelationshipUpdate_OPERATION_TOUCH,
+				Relationship: client.RelationOrganizationSDR(m.OrganizationID, m.ID, products...),
+			})
+		default:
+			return fmt.Errorf("unknown role: %s", m.Role)
 		}
+	}
 
-		if _, err := s.authzC.WriteRelationships(ctx, req); err != nil {
-			return fmt.Errorf("failed to write relationships: %w", err)
-		}
+	if _, err := s.c.WriteRelationshipsBulk(ctx, updates); err != nil {
+		return fmt.Errorf("failed to write relationships: %w", err)
 	}
 
 	return nil
@@ -173,24 +168,16 @@ func (s *Syncer) syncOffDays(ctx context.Context) error {
 		return fmt.Errorf("failed to query off days: %w", err)
 	}
 
-	for i := 0; i < len(offDays); i += batchSize {
-		end := i + batchSize
-		if end > len(offDays) {
-			end = len(offDays)
-		}
-		req := &pb.WriteRelationshipsRequest{
-			Updates: make([]*pb.RelationshipUpdate, 0, batchSize),
-		}
-		for _, d := range offDays[i:end] {
-			req.Updates = append(req.Updates, &pb.RelationshipUpdate{
-				Operation:    pb.RelationshipUpdate_OPERATION_TOUCH,
-				Relationship: client.RelationOffDayOrganization(d.ID, d.OrganizationID),
-			})
-		}
+	updates := make([]*pb.RelationshipUpdate, 0, len(offDays))
+	for _, d := range offDays {
+		updates = append(updates, &pb.RelationshipUpdate{
+			Operation:    pb.RelationshipUpdate_OPERATION_TOUCH,
+			Relationship: client.RelationOffDayOrganization(d.ID, d.OrganizationID),
+		})
+	}
 
-		if _, err := s.authzC.WriteRelationships(ctx, req); err != nil {
-			return fmt.Errorf("failed to write relationships: %w", err)
-		}
+	if _, err := s.c.WriteRelationshipsBulk(ctx, updates); err != nil {
+		return fmt.Errorf("failed to write relationships: %w", err)
 	}
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -33,7 +34,7 @@ func (m *mockDatabase) SyncCompleted(ctx context.Context) error {
 
 func (m *mockDatabase) Members(ctx context.Context) ([]*memdb.Member, error) {
 	return []*memdb.Member{
-		{ID: "alice", OrganizationID: "rift", Role: memdb.RoleAdmin},
+		{ID: "alice", OrganizationID: "rift", Role: memdb.RoleAdmin, Products: []string{"sequences", "meetings"}},
 		{ID: "bob", OrganizationID: "rift", Role: memdb.RoleSDR},
 	}, nil
 }
@@ -93,12 +94,14 @@ func TestSyncerSync(t *testing.T) {
 
 				relStrs := make([]string, len(rels))
 				for i, rel := range rels {
-					relStrs[i] = tuple.MustStringRelationship(rel)
+					// caveat context is formatted with protojson,
+					// which randomly adds spaces to the output
+					relStrs[i] = strings.ReplaceAll(tuple.MustStringRelationship(rel), " ", "")
 				}
 
 				sort.Strings(relStrs)
 				assert.Equal(t, relStrs, []string{
-					`organization:rift#admin@member:alice[products:{"enabled":[]}]`,
+					`organization:rift#admin@member:alice[products:{"enabled":["sequences","meetings"]}]`,
 					`organization:rift#sdr@member:bob[products:{"enabled":[]}]`,
 				})
 			})
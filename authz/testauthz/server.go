@@ -10,9 +10,12 @@ import (
 	"github.com/authzed/spicedb/pkg/cmd/datastore"
 	"github.com/authzed/spicedb/pkg/cmd/server"
 	"github.com/authzed/spicedb/pkg/cmd/util"
+	"google.golang.org/grpc"
 )
 
-func StartMemServer(ctx context.Context) (*authzed.ClientWithExperimental, error) {
+// StartMemServer starts in-memory spicedb server and returns the client
+// connected to it with the given dial options.
+func StartMemServer(ctx context.Context, opts ...grpc.DialOption) (*authzed.ClientWithExperimental, error) {
 	ds, err := datastore.NewDatastore(
 		ctx,
 		datastore.DefaultDatastoreConfig().ToOption(),
@@ -49,7 +52,7 @@ func StartMemServer(ctx context.Context) (*authzed.ClientWithExperimental, error
 		}
 	}()
 
-	conn, err := srv.GRPCDialContext(ctx)
+	conn, err := srv.GRPCDialContext(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,39 @@
+// authzgen generates the typed authz client from the spicedb schema.
+//
+// It emits definition, relation, permission and caveat constants,
+// relationship constructors, and permission checks and lookups
+// for every definition in the schema.
+//
+// Usage:
+//
+//	go run ./cmd/authzgen -schema schemas/v2.zed -out schema_gen.go
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+)
+
+func main() {
+	var (
+		schemaPath = flag.String("schema", "schemas/v2.zed", "path to the spicedb schema")
+		outPath    = flag.String("out", "schema_gen.go", "path to the generated file")
+		pkg        = flag.String("package", "client", "package name of the generated file")
+	)
+	flag.Parse()
+
+	schema, err := os.ReadFile(*schemaPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(*pkg, *schemaPath, string(schema))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*outPath, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
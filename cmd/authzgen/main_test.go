@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"rift/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("up_to_date", func(t *testing.T) {
+		schema, err := os.ReadFile("../../authz/client/schemas/v2.zed")
+		assert.NoError(t, err)
+
+		want, err := os.ReadFile("../../authz/client/schema_gen.go")
+		assert.NoError(t, err)
+
+		got, err := generate("client", "schemas/v2.zed", string(schema))
+		assert.NoError(t, err)
+		assert.Equalf(t, string(got), string(want), "schema_gen.go is out of date, run go generate ./authz/client")
+	})
+
+	t.Run("subject_relation", func(t *testing.T) {
+		schema := `
+definition member {}
+
+definition team {
+    relation member: member
+    permission view = member
+}
+
+definition document {
+    relation viewer: team#member
+    permission view = viewer
+}`
+		_, err := generate("client", "test.zed", schema)
+		assert.ErrorContains(t, err, "document#viewer: only direct subjects are supported")
+	})
+
+	t.Run("invalid_schema", func(t *testing.T) {
+		_, err := generate("client", "test.zed", "definition member {")
+		assert.ErrorContains(t, err, "authzgen: parse test.zed")
+	})
+}
@@ -0,0 +1,533 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// words maps schema words to their go spelling,
+// if it's not just the capitalized word.
+var words = map[string]string{
+	"apikey": "ApiKey",
+	"offday": "OffDay",
+	"sdr":    "SDR",
+}
+
+// caveatArgs describes how caveat context is passed
+// to the generated relationship constructors.
+var caveatArgs = map[string]caveatArg{
+	"products": {
+		Param:   "products ...Product",
+		Args:    "products...",
+		Context: "newCaveatProducts(productsToStrings(products))",
+	},
+	"chameleon_email": {
+		Param:   "email string",
+		Args:    "email",
+		Context: "newCaveatChameleonEmail(email)",
+	},
+}
+
+// principals are subject definitions accepted by permission checks
+// and resource lookups as client.Principal, e.g. CanViewContact checks
+// both members and api keys. Subject lookups are generated for each of them,
+// e.g. LookupViewContactMembers and LookupViewContactApiKeys.
+var principals = []string{"member", "apikey", "user", "team"}
+
+// owners are definitions which own resources related to them
+// through the relation named after the owner, e.g. sequence#organization.
+// Owned resources are deleted together with the owner, see DeleteOrganization.
+// Other relations with the same naming don't imply ownership,
+// e.g. a sequence#contact is not deleted with the contact.
+var owners = []string{"organization", "sequence"}
+
+// definitionConfigs customizes generated code of definitions.
+var definitionConfigs = map[string]definitionConfig{
+	// there's a single platform resource, see def_platform.go
+	"platform": {skip: true},
+	// role revisions are written with member roles, see products.go
+	"organization/role": {skip: true},
+	// a member may belong to many organizations, see ListOrganizations
+	"organization": {lookup: "access"},
+	"sequence":     {synthetic: []string{"organization_admin", "organization_apikey", "organization_access"}},
+}
+
+// names renames generated methods.
+// An empty name means that the method is hand-written.
+var names = map[string]string{
+	// admin and sdr roles are mutually exclusive, see def_organization.go
+	"WriteOrganizationAdmin": "",
+	"WriteOrganizationSDR":   "",
+
+	"WriteSequenceActionAssignee":  "AssignSequenceAction",
+	"DeleteSequenceActionAssignee": "UnassignSequenceAction",
+	"ListEditSequenceActions":      "ListAssignedSequenceActions",
+
+	"CanOrganizationSequenceAdmin":  "CanSequenceOrganizationAdmin",
+	"CanOrganizationSequenceApiKey": "CanSequenceOrganizationApiKey",
+}
+
+type definitionConfig struct {
+	// skip if true, then only constants are generated.
+	skip bool
+	// lookup is the permission used to look up resources, view by default.
+	lookup string
+	// noList if true, then lookups are not generated.
+	noList bool
+	// synthetic permissions are not reported in capabilities
+	// and lookups are not generated for them.
+	synthetic []string
+}
+
+type caveatArg struct {
+	// Param declares the caveat parameter of the constructor.
+	Param string
+	// Args forwards the caveat parameter to the constructor.
+	Args string
+	// Context builds the caveat context from the caveat parameter.
+	Context string
+}
+
+type Schema struct {
+	Package     string
+	Source      string
+	Caveats     []*Caveat
+	Definitions []*Definition
+	Relations   []*Const
+	Permissions []*Const
+}
+
+type Const struct {
+	Ident   string
+	Value   string
+	Comment string
+}
+
+type Caveat struct {
+	Const *Const
+	Args  []*Const
+}
+
+type Definition struct {
+	Name     string
+	Const    string
+	Type     string // go type of the capability struct, e.g. OffDay
+	Resource string // resource variable, e.g. offDayResource
+	Id       string // id parameter, e.g. offDayId
+	Plural   string
+
+	Relations    []*Relation
+	Permissions  []*Permission
+	Capabilities []*Permission
+	List         bool
+	// Generate if false, then only the definition constant is generated.
+	Generate bool
+
+	// Dependents are definitions owned by this one, see owners.
+	Dependents    []*Dependent
+	DependentsVar string
+}
+
+// Dependent is a definition owned by its parent definition
+// through the relation named after the parent, e.g. sequence#organization.
+type Dependent struct {
+	Definition *Definition
+	Relation   string
+}
+
+// Relation is a relation with a single subject type.
+// Relations with many subject types are split.
+type Relation struct {
+	Name     string
+	Const    string
+	Func     string // relationship constructor
+	Filter   string // relationship filter constructor
+	Write    string
+	Delete   string
+	Lookup   string // subject lookup, only for principals
+	Subject  *Definition
+	Caveat   *Caveat
+	CaveatFn caveatArg
+}
+
+// Permission methods are generated only if any principal
+// may be granted the permission.
+type Permission struct {
+	Name    string
+	Const   string
+	Field   string
+	Can     string
+	Explain string
+	List    string
+	Iterate string
+	Lookups []*Lookup
+}
+
+// Lookup is a subject lookup of a single principal type.
+type Lookup struct {
+	Name    string
+	Subject *Definition
+}
+
+// parse compiles the schema and builds the model of the generated code.
+func parse(pkg, source, schema string) (*Schema, error) {
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source(source),
+		SchemaString: schema,
+	}, compiler.AllowUnprefixedObjectType())
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Schema{Package: pkg, Source: source}
+
+	caveats := make(map[string]*Caveat)
+	for _, cd := range compiled.CaveatDefinitions {
+		c := parseCaveat(cd)
+		caveats[cd.Name] = c
+		s.Caveats = append(s.Caveats, c)
+	}
+
+	namespaces := make(map[string]*core.NamespaceDefinition)
+	defs := make(map[string]*Definition)
+	for _, nd := range compiled.ObjectDefinitions {
+		namespaces[nd.Name] = nd
+		defs[nd.Name] = newDefinition(nd.Name)
+		s.Definitions = append(s.Definitions, defs[nd.Name])
+	}
+
+	relations := make(map[string]*Const)
+	permissions := make(map[string]*Const)
+	for _, nd := range compiled.ObjectDefinitions {
+		for _, rel := range nd.Relation {
+			if rel.UsersetRewrite != nil {
+				permissions[rel.Name] = &Const{Ident: "permission" + camel(rel.Name), Value: rel.Name}
+			} else {
+				relations[rel.Name] = &Const{Ident: "relation" + camel(rel.Name), Value: rel.Name}
+			}
+		}
+	}
+	s.Relations = sortedConsts(relations)
+	s.Permissions = sortedConsts(permissions)
+
+	r := reachability{namespaces: namespaces}
+	for _, nd := range compiled.ObjectDefinitions {
+		cfg := definitionConfigs[nd.Name]
+		if cfg.skip || len(nd.Relation) == 0 {
+			continue
+		}
+		if cfg.lookup == "" {
+			cfg.lookup = "view"
+		}
+
+		def := defs[nd.Name]
+		def.Generate = true
+		def.List = !cfg.noList
+		for _, rel := range nd.Relation {
+			if rel.UsersetRewrite != nil {
+				p := &Permission{
+					Name:  rel.Name,
+					Const: permissions[rel.Name].Ident,
+					Field: camel(rel.Name),
+				}
+				synthetic := slices.Contains(cfg.synthetic, rel.Name)
+				subjects := r.subjects(nd.Name, rel.Name, map[string]bool{})
+				for _, principal := range principals {
+					if !subjects[principal] {
+						continue
+					}
+
+					if p.Can == "" {
+						p.Can = rename(canName(def, rel.Name))
+						p.Explain = "Explain" + strings.TrimPrefix(p.Can, "Can")
+						if def.List && !synthetic {
+							p.List = rename("List" + camel(rel.Name) + def.Plural)
+							p.Iterate = "Iterate" + strings.TrimPrefix(p.List, "List")
+						}
+					}
+					if !synthetic {
+						p.Lookups = append(p.Lookups, &Lookup{
+							Name:    rename("Lookup" + strings.TrimPrefix(canName(def, rel.Name), "Can") + defs[principal].Plural),
+							Subject: defs[principal],
+						})
+					}
+				}
+
+				def.Permissions = append(def.Permissions, p)
+				if synthetic {
+					continue
+				}
+				if rel.Name == cfg.lookup {
+					def.Capabilities = append([]*Permission{p}, def.Capabilities...)
+				} else {
+					def.Capabilities = append(def.Capabilities, p)
+				}
+				continue
+			}
+
+			for i, allowed := range rel.TypeInformation.AllowedDirectRelations {
+				if allowed.GetRelation() != tuple.Ellipsis {
+					return nil, fmt.Errorf("%s#%s: only direct subjects are supported", nd.Name, rel.Name)
+				}
+
+				subject := defs[allowed.Namespace]
+				name := def.Type + camel(rel.Name)
+				if i > 0 {
+					name += subject.Type
+				}
+
+				relation := &Relation{
+					Name:    rel.Name,
+					Const:   relations[rel.Name].Ident,
+					Func:    "Relation" + name,
+					Filter:  "Filter" + name,
+					Write:   rename("Write" + name),
+					Delete:  rename("Delete" + name),
+					Subject: subject,
+				}
+				if slices.Contains(principals, allowed.Namespace) {
+					relation.Lookup = rename("Lookup" + name + "s")
+				}
+				if rc := allowed.RequiredCaveat; rc != nil {
+					arg, ok := caveatArgs[rc.CaveatName]
+					if !ok {
+						return nil, fmt.Errorf("%s#%s: missing caveat %s arguments", nd.Name, rel.Name, rc.CaveatName)
+					}
+					relation.Caveat = caveats[rc.CaveatName]
+					relation.CaveatFn = arg
+				}
+				def.Relations = append(def.Relations, relation)
+			}
+		}
+
+		if len(def.Capabilities) == 0 || def.Capabilities[0].Name != cfg.lookup {
+			return nil, fmt.Errorf("%s: missing lookup permission %s", nd.Name, cfg.lookup)
+		}
+	}
+
+	for _, def := range s.Definitions {
+		def.dependents(s.Definitions)
+	}
+	return s, nil
+}
+
+// dependents finds definitions owned by def, see owners.
+// The owner must be the only subject of the relation.
+func (def *Definition) dependents(defs []*Definition) {
+	if !slices.Contains(owners, def.Name) {
+		return
+	}
+	for _, d := range defs {
+		if d == def {
+			continue
+		}
+
+		var owned *Relation
+		subjects := 0
+		for _, rel := range d.Relations {
+			if rel.Name != def.Name {
+				continue
+			}
+			subjects++
+			if rel.Subject == def {
+				owned = rel
+			}
+		}
+		if owned != nil && subjects == 1 {
+			def.Dependents = append(def.Dependents, &Dependent{Definition: d, Relation: owned.Const})
+		}
+	}
+	if len(def.Dependents) > 0 {
+		def.DependentsVar = lowerFirst(def.Type) + "Dependents"
+	}
+}
+
+func parseCaveat(cd *core.CaveatDefinition) *Caveat {
+	c := &Caveat{
+		Const: &Const{Ident: "caveat" + camel(cd.Name), Value: cd.Name},
+	}
+
+	params := make([]string, 0, len(cd.ParameterTypes))
+	for name := range cd.ParameterTypes {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+
+	for _, name := range params {
+		ident := c.Const.Ident + camel(name) + "Arg"
+		if len(params) == 1 {
+			ident = c.Const.Ident + "Arg"
+		}
+		c.Args = append(c.Args, &Const{
+			Ident:   ident,
+			Value:   name,
+			Comment: caveatType(cd.ParameterTypes[name]),
+		})
+	}
+	return c
+}
+
+func caveatType(ref *core.CaveatTypeReference) string {
+	if len(ref.ChildTypes) == 0 {
+		return ref.TypeName
+	}
+
+	children := make([]string, len(ref.ChildTypes))
+	for i, child := range ref.ChildTypes {
+		children[i] = caveatType(child)
+	}
+	return ref.TypeName + "<" + strings.Join(children, ", ") + ">"
+}
+
+func newDefinition(name string) *Definition {
+	typ := camel(name)
+	plural := typ + "s"
+	if strings.HasSuffix(typ, "x") || strings.HasSuffix(typ, "s") {
+		plural = typ + "es"
+	}
+	return &Definition{
+		Name:     name,
+		Const:    "definition" + typ,
+		Type:     typ,
+		Resource: lowerFirst(typ) + "Resource",
+		Id:       lowerFirst(typ) + "Id",
+		Plural:   plural,
+	}
+}
+
+// canName builds permission check name with the definition
+// put after the verb, e.g. create_call_step on sequence
+// is CanCreateSequenceCallStep.
+func canName(def *Definition, permission string) string {
+	verb, rest, _ := strings.Cut(permission, "_")
+	return "Can" + camel(verb) + def.Type + camel(rest)
+}
+
+func rename(name string) string {
+	if renamed, ok := names[name]; ok {
+		return renamed
+	}
+	return name
+}
+
+// reachability finds subject types which may be granted a permission.
+type reachability struct {
+	namespaces map[string]*core.NamespaceDefinition
+}
+
+func (r reachability) subjects(definition, name string, seen map[string]bool) map[string]bool {
+	key := definition + "#" + name
+	out := make(map[string]bool)
+	if seen[key] {
+		return out
+	}
+	// seen guards against cycles, so it only holds the current path
+	seen[key] = true
+	defer delete(seen, key)
+
+	rel := r.relation(definition, name)
+	if rel == nil {
+		return out
+	}
+
+	if rel.UsersetRewrite == nil {
+		for _, allowed := range rel.TypeInformation.AllowedDirectRelations {
+			out[allowed.Namespace] = true
+		}
+		return out
+	}
+
+	r.rewrite(definition, rel.UsersetRewrite, seen, out)
+	return out
+}
+
+func (r reachability) rewrite(definition string, rewrite *core.UsersetRewrite, seen, out map[string]bool) {
+	var children []*core.SetOperation_Child
+	switch {
+	case rewrite.GetUnion() != nil:
+		children = rewrite.GetUnion().Child
+	case rewrite.GetIntersection() != nil:
+		children = rewrite.GetIntersection().Child
+	case rewrite.GetExclusion() != nil:
+		// only the base set grants the permission
+		children = rewrite.GetExclusion().Child[:1]
+	}
+
+	for _, child := range children {
+		switch {
+		case child.GetComputedUserset() != nil:
+			merge(out, r.subjects(definition, child.GetComputedUserset().Relation, seen))
+		case child.GetTupleToUserset() != nil:
+			ttu := child.GetTupleToUserset()
+			for subject := range r.subjects(definition, ttu.Tupleset.Relation, seen) {
+				merge(out, r.subjects(subject, ttu.ComputedUserset.Relation, seen))
+			}
+		case child.GetUsersetRewrite() != nil:
+			r.rewrite(definition, child.GetUsersetRewrite(), seen, out)
+		}
+	}
+}
+
+func (r reachability) relation(definition, name string) *core.Relation {
+	for _, rel := range r.namespaces[definition].GetRelation() {
+		if rel.Name == name {
+			return rel
+		}
+	}
+	return nil
+}
+
+func merge(dst, src map[string]bool) {
+	for k := range src {
+		dst[k] = true
+	}
+}
+
+func sortedConsts(consts map[string]*Const) []*Const {
+	out := make([]*Const, 0, len(consts))
+	for _, c := range consts {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Value < out[j].Value })
+	return out
+}
+
+// camel converts schema name to go identifier, e.g. sequence/action
+// is SequenceAction and create_offday is CreateOffDay.
+func camel(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '/' }) {
+		if w, ok := words[word]; ok {
+			b.WriteString(w)
+			continue
+		}
+		b.WriteString(upperFirst(word))
+	}
+	return b.String()
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"text/template"
+)
+
+// generate generates the typed client from the schema.
+// The source is the schema path written in the file header.
+func generate(pkg, source, schema string) ([]byte, error) {
+	s, err := parse(pkg, source, schema)
+	if err != nil {
+		return nil, fmt.Errorf("authzgen: parse %s: %w", source, err)
+	}
+
+	var buf bytes.Buffer
+	if err := clientTemplate.Execute(&buf, s); err != nil {
+		return nil, fmt.Errorf("authzgen: execute template: %w", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("authzgen: format source: %w", err)
+	}
+	return src, nil
+}
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by authzgen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+const (
+{{- range .Caveats}}
+	{{.Const.Ident}} = "{{.Const.Value}}"
+{{- range .Args}}
+	{{.Ident}} = "{{.Value}}" // {{.Comment}}
+{{- end}}
+{{end -}}
+)
+
+const (
+{{- range .Definitions}}
+	{{.Const}} = "{{.Name}}"
+{{- end}}
+)
+
+const (
+{{- range .Relations}}
+	{{.Ident}} = "{{.Value}}"
+{{- end}}
+)
+
+const (
+{{- range .Permissions}}
+	{{.Ident}} = "{{.Value}}"
+{{- end}}
+)
+{{range .Definitions}}{{if .Generate}}{{$def := .}}
+// {{.Type}} reports permissions the principal has on the {{.Name}}.
+type {{.Type}} struct {
+	Id string
+{{- range .Capabilities}}
+	{{.Field}} bool
+{{- end}}
+}
+
+var {{.Resource}} = newResource(
+	{{.Const}},
+	[]string{
+{{- range .Capabilities}}
+		{{.Const}},
+{{- end}}
+	},
+	func(id string, granted map[string]bool) *{{.Type}} {
+		return &{{.Type}}{
+			Id: id,
+{{- range .Capabilities}}
+			{{.Field}}: granted[{{.Const}}],
+{{- end}}
+		}
+	},
+)
+{{range .Relations}}
+// {{.Func}} builds {{$def.Name}}#{{.Name}}@{{.Subject.Name}} relationship.
+func {{.Func}}(
+	{{$def.Id}} string,
+	{{.Subject.Id}} string,
+{{- if .Caveat}}
+	{{.CaveatFn.Param}},
+{{- end}}
+) *pb.Relationship {
+{{- if .Caveat}}
+	rel := {{$def.Resource}}.Relation({{$def.Id}}, {{.Const}}, subRef({{.Subject.Const}}, {{.Subject.Id}}))
+	rel.OptionalCaveat = &pb.ContextualizedCaveat{
+		CaveatName: {{.Caveat.Const.Ident}},
+		Context:    {{.CaveatFn.Context}},
+	}
+	return rel
+{{- else}}
+	return {{$def.Resource}}.Relation({{$def.Id}}, {{.Const}}, subRef({{.Subject.Const}}, {{.Subject.Id}}))
+{{- end}}
+}
+
+// {{.Filter}} matches {{$def.Name}}#{{.Name}}@{{.Subject.Name}} relationships
+// of the {{$def.Name}}, or of any {{$def.Name}} if the id is empty.
+func {{.Filter}}({{$def.Id}} string) *pb.RelationshipFilter {
+	return {{$def.Resource}}.Filter({{$def.Id}}, {{.Const}}, {{.Subject.Const}})
+}
+{{if .Write}}
+// {{.Write}} writes {{$def.Name}}#{{.Name}}@{{.Subject.Name}} relationship.
+func (c *Client) {{.Write}}(
+	ctx context.Context,
+	{{$def.Id}} string,
+	{{.Subject.Id}} string,
+{{- if .Caveat}}
+	{{.CaveatFn.Param}},
+{{- end}}
+) (*pb.ZedToken, error) {
+{{- if .Caveat}}
+	return c.writeRelationship(ctx, {{.Func}}({{$def.Id}}, {{.Subject.Id}}, {{.CaveatFn.Args}}))
+{{- else}}
+	return {{$def.Resource}}.Write(ctx, c, {{$def.Id}}, {{.Const}}, subRef({{.Subject.Const}}, {{.Subject.Id}}))
+{{- end}}
+}
+{{end}}{{if .Delete}}
+// {{.Delete}} deletes {{$def.Name}}#{{.Name}}@{{.Subject.Name}} relationship.
+func (c *Client) {{.Delete}}(
+	ctx context.Context,
+	{{$def.Id}} string,
+	{{.Subject.Id}} string,
+) (*pb.ZedToken, error) {
+	return {{$def.Resource}}.Delete(ctx, c, {{$def.Id}}, {{.Const}}, subRef({{.Subject.Const}}, {{.Subject.Id}}))
+}
+{{end}}{{if .Lookup}}
+// {{.Lookup}} streams {{.Subject.Name}} subjects of {{$def.Name}}#{{.Name}} relation.
+func (c *Client) {{.Lookup}}(
+	ctx context.Context,
+	{{$def.Id}} string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return {{$def.Resource}}.LookupSubjects(ctx, c, {{$def.Id}}, {{.Const}}, {{.Subject.Const}}, fn, opts...)
+}
+{{end}}{{end}}
+{{- range .Permissions}}{{if .Can}}
+// {{.Can}} checks if the principal has {{$def.Name}}#{{.Name}} permission.
+func (c *Client) {{.Can}}(
+	ctx context.Context,
+	{{$def.Id}} string,
+	principal Principal,
+	opts ...ReadOption,
+) error {
+	return {{$def.Resource}}.Can(ctx, c, {{$def.Id}}, {{.Const}}, principal.ref(), opts...)
+}
+
+// {{.Explain}} checks the permission like {{.Can}}
+// and returns the trace explaining the result.
+func (c *Client) {{.Explain}}(
+	ctx context.Context,
+	{{$def.Id}} string,
+	principal Principal,
+	opts ...ReadOption,
+) (*Trace, error) {
+	return {{$def.Resource}}.Explain(ctx, c, {{$def.Id}}, {{.Const}}, principal.ref(), opts...)
+}
+{{end}}{{$perm := .}}{{range .Lookups}}
+// {{.Name}} streams {{.Subject.Name}} subjects
+// which have {{$def.Name}}#{{$perm.Name}} permission.
+func (c *Client) {{.Name}}(
+	ctx context.Context,
+	{{$def.Id}} string,
+	fn func(Subject) error,
+	opts ...ReadOption,
+) error {
+	return {{$def.Resource}}.LookupSubjects(ctx, c, {{$def.Id}}, {{$perm.Const}}, {{.Subject.Const}}, fn, opts...)
+}
+{{end}}{{if .List}}
+// {{.List}} returns ids of {{$def.Name}} resources
+// the principal has {{.Name}} permission on.
+func (c *Client) {{.List}}(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) ([]string, error) {
+	return {{$def.Resource}}.List(ctx, c, {{.Const}}, principal.ref(), opts...)
+}
+
+// {{.Iterate}} iterates over ids of {{$def.Name}} resources
+// the principal has {{.Name}} permission on.
+func (c *Client) {{.Iterate}}(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) *Iterator[string] {
+	return {{$def.Resource}}.Iterate(ctx, c, {{.Const}}, principal.ref(), opts...)
+}
+{{end}}{{end}}
+{{- if .List}}
+// List{{.Plural}} returns capabilities of {{.Name}} resources
+// the principal has access to, keyed by resource id.
+func (c *Client) List{{.Plural}}(
+	ctx context.Context,
+	principal Principal,
+	opts ...ReadOption,
+) (map[string]*{{.Type}}, error) {
+	return {{.Resource}}.ListWithCapabilities(ctx, c, principal.ref(), opts...)
+}
+{{end}}{{end}}{{end}}
+
+// schemaRelations are all relations of generated definitions,
+// split by the subject type.
+var schemaRelations = []schemaRelation{
+{{- range .Definitions}}{{$def := .}}{{range .Relations}}
+	{definition: {{$def.Const}}, relation: {{.Const}}, subject: {{.Subject.Const}}},
+{{- end}}{{end}}
+}
+{{- range .Definitions}}{{if .Dependents}}
+// {{.DependentsVar}} are definitions owned by the {{.Name}},
+// they are deleted together with it.
+var {{.DependentsVar}} = []*dependent{
+{{- range .Dependents}}
+	{definition: {{.Definition.Const}}, relation: {{.Relation}}{{if .Definition.Dependents}}, dependents: {{.Definition.DependentsVar}}{{end}}},
+{{- end}}
+}
+{{end}}{{end}}`))
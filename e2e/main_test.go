@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"rift/authz/client"
+	"rift/authz/migrate"
+	"rift/authz/syncer"
 	"rift/httpsrv"
 	"rift/memdb"
 )
@@ -32,7 +34,9 @@ func TestMain(m *testing.M) {
 	// clients
 	db := memdb.New()
 	authzC := die2(client.New(containerSrv.SpicedbHostPort, "spicedb-super-secret"))
-	die(authzC.MigrateSchema(context.Background()))
+	migrations := die2(migrate.Migrations())
+	migrator := die2(migrate.New(authzC, migrate.NewDB(db), syncer.NewLocalMutex(), migrations...))
+	die(migrator.Migrate(context.Background()))
 
 	// http rest
 	mux := httpsrv.New(db, authzC)
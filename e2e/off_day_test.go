@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"rift/assert"
+	"rift/httpsrv"
 	"rift/memdb"
 )
 
@@ -15,7 +16,15 @@ func TestOffDay(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, resp.StatusCode, http.StatusOK)
 
-	resp, err = http.Get(tsURL + "/offdays")
+	// read your writes
+	token := resp.Header.Get(httpsrv.ZedTokenHeader)
+	assert.True(t, token != "")
+
+	req, err := http.NewRequest(http.MethodGet, tsURL+"/offdays", nil)
+	assert.NoError(t, err)
+	req.Header.Set(httpsrv.ZedTokenHeader, token)
+
+	resp, err = http.DefaultClient.Do(req)
 	assert.NoError(t, err)
 	assert.Equal(t, resp.StatusCode, http.StatusOK)
 
@@ -0,0 +1,114 @@
+package httpsrv
+
+import (
+	"context"
+	"net/http"
+
+	"rift/authz/client"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+const (
+	// ZedTokenHeader carries the last authz write token of the client.
+	// It's set in responses of requests which write to authz,
+	// and clients which don't keep cookies should send it back.
+	ZedTokenHeader = "X-Authz-Token"
+
+	// zedTokenCookie carries the same token as ZedTokenHeader
+	// for clients which keep cookies, e.g. browsers.
+	zedTokenCookie = "authz_token"
+)
+
+// withZedToken gives the client read-your-writes consistency across requests,
+// e.g. POST /offdays followed by GET /offdays shows the new off day.
+//
+// The token of the last write is returned to the client in the response
+// header and cookie, and the token sent back by the client is used to read
+// authz data at least as fresh as it. Because the token is kept by the client,
+// it works across many server instances. A token rejected by authz is dropped
+// and reads fall back to the default consistency, see client.WithZedToken,
+// and the cookie is cleared.
+//
+// Requests without a token read at least as fresh as the fallback token,
+// e.g. the token of writes made at startup. It may be nil.
+func withZedToken(next http.Handler, fallback *pb.ZedToken) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := zedTokenFromRequest(r)
+		if token == nil {
+			token = fallback
+		}
+		ctx := client.WithZedToken(r.Context(), token)
+
+		zw := &zedTokenWriter{ResponseWriter: w, ctx: ctx, token: token}
+		next.ServeHTTP(zw, r.WithContext(ctx))
+
+		// handler may not write anything, headers are sent after it returns
+		zw.setToken()
+	})
+}
+
+// zedTokenFromRequest returns the token sent by the client, or nil.
+// The header takes precedence over the cookie.
+func zedTokenFromRequest(r *http.Request) *pb.ZedToken {
+	if token := r.Header.Get(ZedTokenHeader); token != "" {
+		return &pb.ZedToken{Token: token}
+	}
+	if cookie, err := r.Cookie(zedTokenCookie); err == nil && cookie.Value != "" {
+		return &pb.ZedToken{Token: cookie.Value}
+	}
+	return nil
+}
+
+// zedTokenWriter sets the token header and cookie before
+// the response headers are written, if the token has changed.
+type zedTokenWriter struct {
+	http.ResponseWriter
+
+	ctx         context.Context
+	token       *pb.ZedToken
+	wroteHeader bool
+}
+
+func (zw *zedTokenWriter) WriteHeader(code int) {
+	zw.setToken()
+	zw.ResponseWriter.WriteHeader(code)
+}
+
+func (zw *zedTokenWriter) Write(b []byte) (int, error) {
+	zw.setToken()
+	return zw.ResponseWriter.Write(b)
+}
+
+func (zw *zedTokenWriter) setToken() {
+	if zw.wroteHeader {
+		return
+	}
+	zw.wroteHeader = true
+
+	token := client.ZedTokenFromContext(zw.ctx)
+	if token == nil && zw.token != nil {
+		// the server rejected the token of the client,
+		// e.g. a forged one, so the client should not send it again
+		http.SetCookie(zw, &http.Cookie{
+			Name:     zedTokenCookie,
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+		return
+	}
+	if token == nil || token.GetToken() == zw.token.GetToken() {
+		return
+	}
+
+	zw.Header().Set(ZedTokenHeader, token.Token)
+	http.SetCookie(zw, &http.Cookie{
+		Name:     zedTokenCookie,
+		Value:    token.Token,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
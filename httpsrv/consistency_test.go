@@ -0,0 +1,142 @@
+package httpsrv
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"rift/assert"
+	"rift/authz/client"
+
+	pb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+func TestWithZedToken(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := client.StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	var received string
+	handler := withZedToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = client.ZedTokenFromContext(r.Context()).GetToken()
+
+		if r.Method == http.MethodPost {
+			_, err := tclient.WriteOffDayOrganization(r.Context(), "offday", "org")
+			assert.NoError(t, err)
+		}
+	}), nil)
+
+	t.Run("write", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/offdays", nil))
+
+		assert.Equal(t, received, "")
+		assert.True(t, rec.Header().Get(ZedTokenHeader) != "")
+
+		cookies := rec.Result().Cookies()
+		assert.Len(t, cookies, 1)
+		assert.Equal(t, cookies[0].Value, rec.Header().Get(ZedTokenHeader))
+	})
+
+	t.Run("header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/offdays", nil)
+		req.Header.Set(ZedTokenHeader, "header")
+		req.AddCookie(&http.Cookie{Name: zedTokenCookie, Value: "cookie"})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, received, "header")
+		// token has not changed
+		assert.Equal(t, rec.Header().Get(ZedTokenHeader), "")
+	})
+
+	t.Run("cookie", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/offdays", nil)
+		req.AddCookie(&http.Cookie{Name: zedTokenCookie, Value: "cookie"})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, received, "cookie")
+	})
+
+	t.Run("read_your_writes", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/offdays", nil))
+		token := rec.Header().Get(ZedTokenHeader)
+
+		req := httptest.NewRequest(http.MethodGet, "/offdays", nil)
+		req.Header.Set(ZedTokenHeader, token)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Equal(t, received, token)
+	})
+
+	t.Run("garbage_cookie", func(t *testing.T) {
+		_, err := tclient.WriteOrganizationAdmin(ctx, "org", "member")
+		assert.NoError(t, err)
+
+		handler := withZedToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := tclient.CanViewOffDay(r.Context(), "offday", client.MemberPrincipal("member"))
+			assert.NoError(t, err)
+		}), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/offdays/offday", nil)
+		req.AddCookie(&http.Cookie{Name: zedTokenCookie, Value: "garbage"})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, rec.Code, http.StatusOK)
+		cookies := rec.Result().Cookies()
+		assert.Len(t, cookies, 1)
+		assert.Equal(t, cookies[0].Name, zedTokenCookie)
+		assert.Equal(t, cookies[0].MaxAge, -1)
+	})
+
+	t.Run("invalid_read", func(t *testing.T) {
+		token, err := tclient.WriteOrganizationAdmin(ctx, "org", "member")
+		assert.NoError(t, err)
+
+		// the read fails for other reason than the token
+		handler := withZedToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			it := tclient.IterateViewOffDays(r.Context(), client.MemberPrincipal("member"), client.WithPageSize(5000))
+			defer it.Close()
+			for it.Next() {
+			}
+			assert.Error(t, it.Err())
+			http.Error(w, it.Err().Error(), http.StatusInternalServerError)
+		}), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/offdays", nil)
+		req.AddCookie(&http.Cookie{Name: zedTokenCookie, Value: token.Token})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, rec.Code, http.StatusInternalServerError)
+		assert.Len(t, rec.Result().Cookies(), 0)
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		fallback := &pb.ZedToken{Token: "fallback"}
+		handler := withZedToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = client.ZedTokenFromContext(r.Context()).GetToken()
+		}), fallback)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/offdays", nil))
+		assert.Equal(t, received, "fallback")
+		// the fallback is not sent to the client
+		assert.Len(t, rec.Result().Cookies(), 0)
+
+		req := httptest.NewRequest(http.MethodGet, "/offdays", nil)
+		req.Header.Set(ZedTokenHeader, "header")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		assert.Equal(t, received, "header")
+	})
+}
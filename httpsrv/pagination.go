@@ -0,0 +1,60 @@
+package httpsrv
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"rift/authz/client"
+)
+
+// NextCursorHeader carries the cursor of the next page of a list.
+// It's sent back in the cursor query parameter to get the next page,
+// e.g. GET /offdays?limit=50&cursor=...
+const NextCursorHeader = "X-Next-Cursor"
+
+// maxPageLimit is the largest page size accepted by SpiceDB lookups.
+const maxPageLimit = 1000
+
+// pageLimit returns the page size requested with the limit query parameter,
+// zero if there's no limit. Limits above maxPageLimit are rejected.
+func pageLimit(r *http.Request) (uint32, error) {
+	s := r.URL.Query().Get("limit")
+	if s == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil || n == 0 {
+		return 0, fmt.Errorf("invalid limit %q", s)
+	}
+	if n > maxPageLimit {
+		return 0, fmt.Errorf("limit %d exceeds the maximum of %d", n, maxPageLimit)
+	}
+	return uint32(n), nil
+}
+
+// page returns ids of the page starting after the cursor query parameter,
+// and the cursor of the next page, if there may be one.
+// Without the limit all ids are returned.
+func page(
+	r *http.Request,
+	limit uint32,
+	iterate func(opts ...client.ReadOption) *client.Iterator[string],
+) ([]string, string, error) {
+	it := iterate(client.WithPageSize(limit), client.WithCursor(r.URL.Query().Get("cursor")))
+	defer it.Close()
+
+	var ids []string
+	for (limit == 0 || len(ids) < int(limit)) && it.Next() {
+		ids = append(ids, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return nil, "", err
+	}
+
+	if limit == 0 || len(ids) < int(limit) {
+		return ids, "", nil
+	}
+	return ids, it.Cursor(), nil
+}
@@ -0,0 +1,92 @@
+package httpsrv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"testing"
+
+	"rift/assert"
+	"rift/authz/client"
+	"rift/memdb"
+)
+
+func TestPage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tclient, err := client.StartTestServer(ctx)
+	assert.NoError(t, err)
+
+	_, err = tclient.WriteOrganizationAdmin(ctx, "org", "member")
+	assert.NoError(t, err)
+
+	var offDayIds []string
+	for i := 0; i < 5; i++ {
+		offDayId := fmt.Sprintf("offday%d", i)
+		offDayIds = append(offDayIds, offDayId)
+
+		_, err := tclient.WriteOffDayOrganization(ctx, offDayId, "org")
+		assert.NoError(t, err)
+	}
+
+	iterate := func(opts ...client.ReadOption) *client.Iterator[string] {
+		return tclient.IterateViewOffDays(ctx, client.MemberPrincipal("member"), opts...)
+	}
+
+	t.Run("all", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/offdays", nil)
+		ids, next, err := page(r, 0, iterate)
+		assert.NoError(t, err)
+		assert.Len(t, ids, len(offDayIds))
+		assert.Equal(t, next, "")
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		var ids []string
+		cursor := ""
+		for pages := 1; ; pages++ {
+			r := httptest.NewRequest(http.MethodGet, "/offdays?limit=2&cursor="+url.QueryEscape(cursor), nil)
+			limit, err := pageLimit(r)
+			assert.NoError(t, err)
+
+			page, next, err := page(r, limit, iterate)
+			assert.NoError(t, err)
+			ids = append(ids, page...)
+
+			if next == "" {
+				assert.Equal(t, pages, 3)
+				break
+			}
+			cursor = next
+		}
+
+		sort.Strings(ids)
+		assert.Equal(t, ids, offDayIds)
+	})
+
+	t.Run("invalid_limit", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/offdays?limit=abc", nil)
+		_, err := pageLimit(r)
+		assert.ErrorContains(t, err, "invalid limit")
+	})
+
+	t.Run("limit_too_large", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/offdays?limit=1000", nil)
+		limit, err := pageLimit(r)
+		assert.NoError(t, err)
+		assert.Equal(t, limit, uint32(maxPageLimit))
+
+		r = httptest.NewRequest(http.MethodGet, "/offdays?limit=1001", nil)
+		_, err = pageLimit(r)
+		assert.ErrorContains(t, err, "exceeds the maximum")
+
+		// spicedb would reject the page size with an internal error
+		rec := httptest.NewRecorder()
+		New(memdb.New(), tclient).ServeHTTP(rec, r)
+		assert.Equal(t, rec.Code, http.StatusBadRequest)
+	})
+}
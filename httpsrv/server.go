@@ -3,6 +3,7 @@ package httpsrv
 import (
 	"context"
 	"encoding/json"
+	"log"
 	"net/http"
 
 	"rift/authz/client"
@@ -12,25 +13,29 @@ import (
 func New(
 	db *memdb.DB,
 	authzC *client.Client,
-) *http.ServeMux {
+) http.Handler {
 	mux := http.NewServeMux()
 
 	// authn middleware
 	memberId := "member"
+	principal := client.MemberPrincipal(memberId)
 	orgId := "org"
 
 	// this is set during registration/invite/accept/change role/...
-	if err := authzC.WriteOrganizationAdmin(
+	// reads are at least as fresh as it, the default minimize latency
+	// may read a snapshot before it right after the startup
+	bootstrapped, err := authzC.WriteOrganizationAdmin(
 		context.Background(),
 		orgId,
 		memberId,
-	); err != nil {
+	)
+	if err != nil {
 		panic(err)
 	}
 
 	mux.HandleFunc("POST /offdays", func(w http.ResponseWriter, r *http.Request) {
-		if err := authzC.CanCreateOrganizationOffDay(r.Context(), orgId, memberId); err != nil {
-			http.Error(w, err.Error(), http.StatusForbidden)
+		if err := authzC.CanCreateOrganizationOffDay(r.Context(), orgId, principal); err != nil {
+			authzError(w, err)
 			return
 		}
 
@@ -41,17 +46,31 @@ func New(
 		}
 
 		db.AddOffDay(&offDay)
-		authzC.WriteOffDayOrganization(r.Context(), offDay.ID, orgId)
+		if _, err := authzC.WriteOffDayOrganization(r.Context(), offDay.ID, orgId); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 	})
 
 	mux.HandleFunc("GET /offdays", func(w http.ResponseWriter, r *http.Request) {
-		offDayIds, err := authzC.ListViewOffDays(r.Context(), memberId)
+		limit, err := pageLimit(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		offDayIds, next, err := page(r, limit, func(opts ...client.ReadOption) *client.Iterator[string] {
+			return authzC.IterateViewOffDays(r.Context(), principal, opts...)
+		})
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
 		offDays := db.GetOffDays(offDayIds...)
+		if next != "" {
+			w.Header().Set(NextCursorHeader, next)
+		}
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(offDays); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -61,8 +80,8 @@ func New(
 
 	mux.HandleFunc("GET /offdays/{id}", func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
-		if err := authzC.CanViewOffDay(r.Context(), id, memberId); err != nil {
-			http.Error(w, err.Error(), http.StatusForbidden)
+		if err := authzC.CanViewOffDay(r.Context(), id, principal); err != nil {
+			authzError(w, err)
 			return
 		}
 
@@ -81,8 +100,8 @@ func New(
 
 	mux.HandleFunc("PATCH /offdays/{id}", func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
-		if err := authzC.CanEditOffDay(r.Context(), id, memberId); err != nil {
-			http.Error(w, err.Error(), http.StatusForbidden)
+		if err := authzC.CanEditOffDay(r.Context(), id, principal); err != nil {
+			authzError(w, err)
 			return
 		}
 
@@ -98,17 +117,32 @@ func New(
 
 	mux.HandleFunc("DELETE /offdays/{id}", func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
-		if err := authzC.CanDeleteOffDay(r.Context(), id, memberId); err != nil {
-			http.Error(w, err.Error(), http.StatusForbidden)
+		if err := authzC.CanDeleteOffDay(r.Context(), id, principal); err != nil {
+			authzError(w, err)
 			return
 		}
 
 		db.DeleteOffDay(id)
 
-		if err := authzC.DeleteOffDayOrganization(r.Context(), id, orgId); err != nil {
+		if _, err := authzC.DeleteOffDayOrganization(r.Context(), id, orgId); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 	})
-	return mux
+	return withZedToken(mux, bootstrapped)
+}
+
+// authzError responds to the failed permission check.
+// Conditional denial means the handler didn't pass the caveat context,
+// so it's logged and reported as the server error, not as no access.
+func authzError(w http.ResponseWriter, err error) {
+	switch {
+	case client.IsConditionalDenied(err):
+		log.Printf("httpsrv: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	case client.IsDenied(err):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
 }
@@ -0,0 +1,31 @@
+package httpsrv
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"rift/assert"
+	"rift/authz/client"
+)
+
+func TestAuthzError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"denied", &client.ErrDenied{}, http.StatusForbidden},
+		{"conditional", &client.ErrDenied{Conditional: true}, http.StatusInternalServerError},
+		{"other", errors.New("authz: unavailable"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			authzError(rec, tt.err)
+			assert.Equal(t, rec.Code, tt.code)
+		})
+	}
+}
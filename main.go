@@ -6,6 +6,8 @@ import (
 	"net/http"
 
 	"rift/authz/client"
+	"rift/authz/migrate"
+	"rift/authz/syncer"
 	"rift/httpsrv"
 	"rift/memdb"
 )
@@ -19,7 +21,18 @@ func main() {
 		log.Fatal(err)
 	}
 
-	if err := authzC.MigrateSchema(context.Background()); err != nil {
+	migrations, err := migrate.Migrations()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// NOTE: use redis mutex with migrate.LockKey when running many instances
+	migrator, err := migrate.New(authzC, migrate.NewSchemaDB(authzC), syncer.NewLocalMutex(), migrations...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := migrator.Migrate(context.Background()); err != nil {
 		log.Fatal(err)
 	}
 
@@ -13,6 +13,7 @@ type Member struct {
 	ID             string
 	OrganizationID string
 	Role           Role
+	Products       []string
 }
 
 type OffDay struct {
@@ -24,6 +25,9 @@ type DB struct {
 	members map[string]*Member
 	offDays map[string]*OffDay
 
+	// authzSchemaVersion is the applied authz schema version.
+	authzSchemaVersion int
+
 	mx sync.RWMutex
 }
 
@@ -111,3 +115,16 @@ func (db *DB) DeleteOffDay(id string) {
 	delete(db.offDays, id)
 	db.mx.Unlock()
 }
+
+func (db *DB) AuthzSchemaVersion() int {
+	db.mx.RLock()
+	v := db.authzSchemaVersion
+	db.mx.RUnlock()
+	return v
+}
+
+func (db *DB) SetAuthzSchemaVersion(v int) {
+	db.mx.Lock()
+	db.authzSchemaVersion = v
+	db.mx.Unlock()
+}